@@ -0,0 +1,132 @@
+package pushpad
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// Client holds the credentials and settings used to call the Pushpad API.
+// Create one with NewClient; the zero value is not usable. A Client is safe
+// for concurrent use.
+type Client struct {
+	mu          sync.RWMutex
+	authToken   string
+	projectID   int64
+	credentials CredentialsProvider
+	baseURLs    []string
+	health      *endpointHealth
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	middleware  []Middleware
+	retryPolicy RetryPolicy
+	idempotency *idempotencyStore
+
+	rateLimiter      *RateLimiter
+	endpointLimiters map[EndpointClass]*RateLimiter
+
+	logger          *slog.Logger
+	debugBodies     bool
+	instrumentation []Instrumentation
+
+	validateParams bool
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the base URL of the API (defaults to DefaultBaseURL).
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURLs = []string{baseURL}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to perform requests (defaults to http.DefaultClient).
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithParamsValidation validates the params of API calls on the client side
+// before sending them, e.g. with NotificationCreateParams.Validate, so that
+// invalid params fail without a round trip.
+func WithParamsValidation() ClientOption {
+	return func(c *Client) {
+		c.validateParams = true
+	}
+}
+
+// ValidatesParams reports whether the client was created with WithParamsValidation.
+func (c *Client) ValidatesParams() bool {
+	return c.validateParams
+}
+
+// NewClient returns a client with its own credentials and default project.
+func NewClient(authToken string, projectID int64, opts ...ClientOption) *Client {
+	c := &Client{
+		authToken:   authToken,
+		projectID:   projectID,
+		baseURLs:    []string{DefaultBaseURL},
+		health:      newEndpointHealth(),
+		idempotency: newIdempotencyStore(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.transport != nil {
+		httpClient := http.Client{}
+		if c.httpClient != nil {
+			httpClient = *c.httpClient
+		}
+		httpClient.Transport = c.transport
+		c.httpClient = &httpClient
+	}
+	return c
+}
+
+// AuthToken returns the auth token of the client.
+func (c *Client) AuthToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authToken
+}
+
+// ProjectID returns the default project ID of the client.
+func (c *Client) ProjectID() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.projectID
+}
+
+// BaseURL returns the base URL of the API used by the client.
+// When several base URLs are configured, it returns the first one.
+func (c *Client) BaseURL() string {
+	return c.baseURLs[0]
+}
+
+// BaseURLs returns the base URLs of the API used by the client, in order of preference.
+func (c *Client) BaseURLs() []string {
+	return append([]string(nil), c.baseURLs...)
+}
+
+// ResolveProjectID returns the provided project ID or the default project ID of the client.
+func (c *Client) ResolveProjectID(projectID *int64) (int64, error) {
+	if projectID != nil && *projectID != 0 {
+		return *projectID, nil
+	}
+	defaultProjectID := c.ProjectID()
+	if defaultProjectID == 0 {
+		return 0, fmt.Errorf("pushpad: project ID is required")
+	}
+	return defaultProjectID, nil
+}
+
+func (c *Client) client() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,43 @@
+// Package client groups the Pushpad resource services around a single pushpad.Client.
+//
+// Use it when an application needs to talk to several Pushpad accounts at once:
+//
+//	api := client.New("AUTH_TOKEN", 123)
+//	res, err := api.Notifications.Create(&notification.NotificationCreateParams{
+//	  Body: pushpad.String("Hello"),
+//	})
+package client
+
+import (
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/notification"
+	"github.com/pushpad/pushpad-go/project"
+	"github.com/pushpad/pushpad-go/sender"
+	"github.com/pushpad/pushpad-go/subscription"
+)
+
+// API exposes the resource services that share one pushpad.Client. The
+// services are interfaces, so tests can replace any of them with a fake.
+type API struct {
+	Client        *pushpad.Client
+	Notifications notification.NotificationService
+	Subscriptions subscription.SubscriptionService
+	Projects      project.ProjectService
+	Senders       sender.SenderService
+}
+
+// New returns an API with its own credentials and default project.
+func New(authToken string, projectID int64, opts ...pushpad.ClientOption) *API {
+	return NewFromClient(pushpad.NewClient(authToken, projectID, opts...))
+}
+
+// NewFromClient returns an API that uses an existing pushpad.Client.
+func NewFromClient(c *pushpad.Client) *API {
+	return &API{
+		Client:        c,
+		Notifications: notification.NewClient(c),
+		Subscriptions: subscription.NewClient(c),
+		Projects:      project.NewClient(c),
+		Senders:       sender.NewClient(c),
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/notification"
+)
+
+func TestMultipleAccounts(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization")+" "+r.URL.Path)
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":1}`))
+	}))
+	defer server.Close()
+
+	first := New("TOKEN1", 1, pushpad.WithBaseURL(server.URL))
+	second := New("TOKEN2", 2, pushpad.WithBaseURL(server.URL))
+
+	params := &notification.NotificationCreateParams{Body: pushpad.String("Hello")}
+	if _, err := first.Notifications.Create(params); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, err := second.Notifications.Create(params); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if len(authorizations) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(authorizations))
+	}
+	if authorizations[0] != "Bearer TOKEN1 /projects/1/notifications" {
+		t.Errorf("unexpected first request %q", authorizations[0])
+	}
+	if authorizations[1] != "Bearer TOKEN2 /projects/2/notifications" {
+		t.Errorf("unexpected second request %q", authorizations[1])
+	}
+}
+
+type fakeNotifications struct {
+	notification.NotificationService
+	created []*notification.NotificationCreateParams
+}
+
+func (f *fakeNotifications) Create(params *notification.NotificationCreateParams) (*notification.NotificationCreateResponse, error) {
+	f.created = append(f.created, params)
+	return &notification.NotificationCreateResponse{ID: 42}, nil
+}
+
+func TestFakeService(t *testing.T) {
+	fake := &fakeNotifications{}
+	api := &API{Notifications: fake}
+
+	res, err := api.Notifications.Create(&notification.NotificationCreateParams{Body: pushpad.String("Hello")})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if res.ID != 42 || len(fake.created) != 1 || *fake.created[0].Body != "Hello" {
+		t.Errorf("expected the fake to be called, got %+v", fake.created)
+	}
+}
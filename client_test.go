@@ -0,0 +1,49 @@
+package pushpad
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewClient(t *testing.T) {
+	httpClient := &http.Client{}
+	c := NewClient("TOKEN", 123, WithBaseURL("http://localhost:3000/api/v1"), WithHTTPClient(httpClient))
+
+	if c.AuthToken() != "TOKEN" {
+		t.Errorf("got %q instead of TOKEN", c.AuthToken())
+	}
+	if c.ProjectID() != 123 {
+		t.Errorf("got %d instead of project ID 123", c.ProjectID())
+	}
+	if c.BaseURL() != "http://localhost:3000/api/v1" {
+		t.Errorf("got %q instead of the custom base URL", c.BaseURL())
+	}
+	if c.client() != httpClient {
+		t.Errorf("expected the custom HTTP client")
+	}
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient("TOKEN", 0)
+
+	if c.BaseURL() != DefaultBaseURL {
+		t.Errorf("got %q instead of %q", c.BaseURL(), DefaultBaseURL)
+	}
+	if c.client() != http.DefaultClient {
+		t.Errorf("expected http.DefaultClient")
+	}
+	if _, err := c.ResolveProjectID(nil); err == nil || err.Error() != "pushpad: project ID is required" {
+		t.Errorf("expected project ID required error, got %v", err)
+	}
+}
+
+func TestClientSignatureFor(t *testing.T) {
+	c := NewClient("5374d7dfeffa2eb49965624ba7596a09", 123)
+
+	got, err := c.SignatureFor("user12345")
+	want := "6627820dab00a1971f2a6d3ff16a5ad8ba4048a02b2d402820afc61aefd0b69f"
+
+	if err != nil || got != want {
+		t.Errorf("got %q (%v), want %q", got, err, want)
+	}
+}
@@ -0,0 +1,240 @@
+package pushpad
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvAuthToken  = "PUSHPAD_AUTH_TOKEN"
+	EnvProjectID  = "PUSHPAD_PROJECT_ID"
+	EnvBaseURL    = "PUSHPAD_BASE_URL"
+	EnvProfile    = "PUSHPAD_PROFILE"
+	EnvConfigFile = "PUSHPAD_CONFIG_FILE"
+)
+
+// Profile holds the settings stored under a name in a config file.
+type Profile struct {
+	AuthToken string
+	ProjectID int64
+	BaseURL   string
+}
+
+// NewClientFromEnv returns a client configured from the environment.
+//
+// If PUSHPAD_PROFILE is set, the profile is loaded first from the config file
+// (PUSHPAD_CONFIG_FILE or DefaultConfigFile). Then PUSHPAD_AUTH_TOKEN,
+// PUSHPAD_PROJECT_ID and PUSHPAD_BASE_URL override the values of the profile.
+// The options are applied after the configuration.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	var profile Profile
+	if name := os.Getenv(EnvProfile); name != "" {
+		path := os.Getenv(EnvConfigFile)
+		if path == "" {
+			var err error
+			path, err = DefaultConfigFile()
+			if err != nil {
+				return nil, err
+			}
+		}
+		loaded, err := LoadProfile(path, name)
+		if err != nil {
+			return nil, err
+		}
+		profile = *loaded
+	}
+
+	if token := os.Getenv(EnvAuthToken); token != "" {
+		profile.AuthToken = token
+	}
+	if value := os.Getenv(EnvProjectID); value != "" {
+		projectID, err := parseProjectID(value)
+		if err != nil {
+			return nil, fmt.Errorf("pushpad: invalid %s: %w", EnvProjectID, err)
+		}
+		profile.ProjectID = projectID
+	}
+	if baseURL := os.Getenv(EnvBaseURL); baseURL != "" {
+		profile.BaseURL = baseURL
+	}
+
+	return profile.NewClient(opts...), nil
+}
+
+// NewClientFromProfile returns a client configured from a profile of a config file.
+func NewClientFromProfile(path, name string, opts ...ClientOption) (*Client, error) {
+	profile, err := LoadProfile(path, name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewClient(opts...), nil
+}
+
+// NewClient returns a client with the settings of the profile.
+func (p *Profile) NewClient(opts ...ClientOption) *Client {
+	if p.BaseURL != "" {
+		opts = append([]ClientOption{WithBaseURL(p.BaseURL)}, opts...)
+	}
+	return NewClient(p.AuthToken, p.ProjectID, opts...)
+}
+
+// DefaultConfigFile returns the path of the default config file,
+// pushpad/config.toml in the user configuration directory.
+func DefaultConfigFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("pushpad: cannot find the config directory: %w", err)
+	}
+	return filepath.Join(dir, "pushpad", "config.toml"), nil
+}
+
+// LoadProfile reads a profile from a config file. The file contains named
+// profiles with the auth_token, project_id and base_url keys, either as JSON:
+//
+//	{"default": {"auth_token": "TOKEN", "project_id": 123}}
+//
+// or as TOML-style sections:
+//
+//	[default]
+//	auth_token = "TOKEN"
+//	project_id = 123
+func LoadProfile(path, name string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pushpad: cannot read config file: %w", err)
+	}
+
+	var profiles map[string]map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		profiles, err = parseJSONConfig(data)
+	} else {
+		profiles, err = parseTOMLConfig(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pushpad: invalid config file %s: %w", path, err)
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("pushpad: profile %q not found in %s", name, path)
+	}
+
+	profile := &Profile{}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := values[key]
+		switch key {
+		case "auth_token":
+			profile.AuthToken = value
+		case "project_id":
+			profile.ProjectID, err = parseProjectID(value)
+			if err != nil {
+				return nil, fmt.Errorf("pushpad: invalid project_id in profile %q of %s: %w", name, path, err)
+			}
+		case "base_url":
+			profile.BaseURL = value
+		default:
+			return nil, fmt.Errorf("pushpad: unknown key %q in profile %q of %s", key, name, path)
+		}
+	}
+	return profile, nil
+}
+
+func parseProjectID(value string) (int64, error) {
+	projectID, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || projectID <= 0 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return projectID, nil
+}
+
+func parseJSONConfig(data []byte) (map[string]map[string]string, error) {
+	var raw map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	profiles := map[string]map[string]string{}
+	for name, fields := range raw {
+		profiles[name] = map[string]string{}
+		for key, value := range fields {
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				// numbers and other literals are kept as written
+				s = string(value)
+			}
+			profiles[name][key] = s
+		}
+	}
+	return profiles, nil
+}
+
+// parseTOMLConfig parses the subset of TOML used by config files: sections,
+// comments and key = value pairs with basic ("...") or literal ('...') strings
+// or bare values.
+func parseTOMLConfig(data []byte) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var section map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", lineNumber)
+			}
+			name := strings.Trim(strings.TrimSpace(line[1:len(line)-1]), `"`)
+			section = map[string]string{}
+			profiles[name] = section
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if section == nil {
+			return nil, fmt.Errorf("line %d: key outside of a profile section", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if rest := strings.TrimSpace(value[len(quoted):]); err != nil || (rest != "" && !strings.HasPrefix(rest, "#")) {
+				return nil, fmt.Errorf("line %d: invalid string %s", lineNumber, value)
+			}
+			value, _ = strconv.Unquote(quoted)
+		} else if strings.HasPrefix(value, "'") {
+			// literal strings have no escapes and end at the next quote
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: invalid string %s", lineNumber, value)
+			}
+			if rest := strings.TrimSpace(value[end+2:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: invalid string %s", lineNumber, value)
+			}
+			value = value[1 : end+1]
+		} else if i := strings.Index(value, "#"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		section[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
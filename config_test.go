@@ -0,0 +1,138 @@
+package pushpad
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("cannot write config file: %s", err)
+	}
+	return path
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvAuthToken, "ENV_TOKEN")
+	t.Setenv(EnvProjectID, "456")
+	t.Setenv(EnvBaseURL, "http://localhost:3000/api/v1")
+
+	c, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if c.AuthToken() != "ENV_TOKEN" || c.ProjectID() != 456 || c.BaseURL() != "http://localhost:3000/api/v1" {
+		t.Errorf("unexpected client %q %d %q", c.AuthToken(), c.ProjectID(), c.BaseURL())
+	}
+}
+
+func TestNewClientFromEnvMalformedProjectID(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvAuthToken, "ENV_TOKEN")
+	t.Setenv(EnvProjectID, "abc")
+
+	_, err := NewClientFromEnv()
+	if err == nil || err.Error() != `pushpad: invalid PUSHPAD_PROJECT_ID: "abc" is not a positive integer` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestNewClientFromEnvWithProfile(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+# local development
+[default]
+auth_token = "DEFAULT_TOKEN"
+project_id = 1
+
+[staging]
+auth_token = "STAGING_TOKEN" # comment
+project_id = 2
+base_url = "https://staging.example.com/api/v1"
+`)
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvProfile, "staging")
+	t.Setenv(EnvAuthToken, "")
+	t.Setenv(EnvProjectID, "3")
+	t.Setenv(EnvBaseURL, "")
+
+	c, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if c.AuthToken() != "STAGING_TOKEN" {
+		t.Errorf("expected the token of the profile, got %q", c.AuthToken())
+	}
+	if c.ProjectID() != 3 {
+		t.Errorf("expected the environment to override the project ID, got %d", c.ProjectID())
+	}
+	if c.BaseURL() != "https://staging.example.com/api/v1" {
+		t.Errorf("expected the base URL of the profile, got %q", c.BaseURL())
+	}
+}
+
+func TestLoadProfileJSON(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"default": {"auth_token": "TOKEN", "project_id": 123}, "other": {"project_id": "456"}}`)
+
+	profile, err := LoadProfile(path, "default")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if profile.AuthToken != "TOKEN" || profile.ProjectID != 123 {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+
+	profile, err = LoadProfile(path, "other")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if profile.ProjectID != 456 {
+		t.Errorf("expected project ID 456, got %d", profile.ProjectID)
+	}
+}
+
+func TestLoadProfileLiteralStrings(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+[default]
+auth_token = 'TOKEN\n' # no escapes in literal strings
+project_id = '1'
+base_url = 'https://example.com/api/v1'
+`)
+	profile, err := LoadProfile(path, "default")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if profile.AuthToken != `TOKEN\n` || profile.ProjectID != 1 || profile.BaseURL != "https://example.com/api/v1" {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		want    string
+	}{
+		{"config.toml", "[default]\nproject_id = -1\n", "default", `invalid project_id in profile "default"`},
+		{"config.toml", "[default]\nproject_id = 12a\n", "default", `"12a" is not a positive integer`},
+		{"config.json", `{"default": {"project_id": 1.5}}`, "default", `"1.5" is not a positive integer`},
+		{"config.toml", "[default]\nauth_tokn = \"x\"\n", "default", `unknown key "auth_tokn"`},
+		{"config.toml", "[default]\nauth_token\n", "default", "line 2: expected key = value"},
+		{"config.toml", "auth_token = \"x\"\n", "default", "line 1: key outside of a profile section"},
+		{"config.toml", "[default]\nauth_token = 'x\n", "default", "line 2: invalid string 'x"},
+		{"config.toml", "[default]\nauth_token = 'x' y\n", "default", "line 2: invalid string 'x' y"},
+		{"config.toml", "[default]\n", "production", `profile "production" not found`},
+	}
+	for _, tt := range tests {
+		path := writeConfigFile(t, tt.name, tt.content)
+		_, err := LoadProfile(path, tt.profile)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.content, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package pushpad
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider returns the auth token for API requests. It is called
+// before every request, so the token can be rotated without restarting the
+// process. Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	AuthToken(ctx context.Context) (string, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (string, error)
+
+// AuthToken calls f(ctx).
+func (f CredentialsProviderFunc) AuthToken(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// WithCredentialsProvider sets a provider that is asked for the auth token
+// before every request, instead of using the token passed to NewClient.
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// FileCredentials returns a provider that reads the auth token from a file,
+// such as a mounted secret. The file is read again whenever its modification
+// time or size changes. Leading and trailing whitespace is ignored.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+func (f *fileCredentials) AuthToken(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("pushpad: cannot read credentials: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("pushpad: cannot read credentials: %w", err)
+	}
+	f.token = strings.TrimSpace(string(data))
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return f.token, nil
+}
+
+// SetCredentials replaces the auth token and the default project ID of the
+// client. It is safe to call while requests are in progress.
+func (c *Client) SetCredentials(authToken string, projectID int64) {
+	c.mu.Lock()
+	c.authToken = authToken
+	c.projectID = projectID
+	c.mu.Unlock()
+}
+
+// currentAuthToken returns the token from the credentials provider, if any,
+// or the token of the client.
+func (c *Client) currentAuthToken(ctx context.Context) (string, error) {
+	if c.credentials != nil {
+		return c.credentials.AuthToken(ctx)
+	}
+	return c.AuthToken(), nil
+}
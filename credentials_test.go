@@ -0,0 +1,111 @@
+package pushpad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCredentialsProviderIsCalledPerRequest(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	tokens := []string{"FIRST", "SECOND"}
+	provider := CredentialsProviderFunc(func(ctx context.Context) (string, error) {
+		token := tokens[0]
+		tokens = tokens[1:]
+		return token, nil
+	})
+
+	c := NewClient("", 123, WithBaseURL(server.URL), WithCredentialsProvider(provider))
+	for i := 0; i < 2; i++ {
+		if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+	if len(authorizations) != 2 || authorizations[0] != "Bearer FIRST" || authorizations[1] != "Bearer SECOND" {
+		t.Errorf("unexpected authorizations %v", authorizations)
+	}
+}
+
+func TestCredentialsProviderError(t *testing.T) {
+	providerErr := errors.New("vault unavailable")
+	provider := CredentialsProviderFunc(func(ctx context.Context) (string, error) {
+		return "", providerErr
+	})
+
+	c := NewClient("", 123, WithCredentialsProvider(provider))
+	if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); !errors.Is(err, providerErr) {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+	if signature, err := c.SignatureForWithContext(context.Background(), "user1"); !errors.Is(err, providerErr) || signature != "" {
+		t.Errorf("expected the provider error and an empty signature, got %q (%v)", signature, err)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("TOKEN1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := FileCredentials(path)
+	token, err := provider.AuthToken(context.Background())
+	if err != nil || token != "TOKEN1" {
+		t.Fatalf("expected TOKEN1, got %q (%v)", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("TOKEN22\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Minute))
+	token, err = provider.AuthToken(context.Background())
+	if err != nil || token != "TOKEN22" {
+		t.Fatalf("expected the rotated token TOKEN22, got %q (%v)", token, err)
+	}
+
+	os.Remove(path)
+	if _, err := provider.AuthToken(context.Background()); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestConcurrentConfigure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 1, WithBaseURL(server.URL))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.SetCredentials("ROTATED", 2)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+				t.Errorf("expected no error, got %s", err)
+			}
+			c.SignatureFor("user1")
+			c.ResolveProjectID(nil)
+		}()
+	}
+	wg.Wait()
+
+	if c.AuthToken() != "ROTATED" || c.ProjectID() != 2 {
+		t.Errorf("expected the rotated credentials, got %q %d", c.AuthToken(), c.ProjectID())
+	}
+}
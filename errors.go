@@ -0,0 +1,186 @@
+package pushpad
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matched by APIError with errors.Is.
+var (
+	ErrBadRequest   = errors.New("pushpad: bad request")
+	ErrUnauthorized = errors.New("pushpad: unauthorized")
+	ErrForbidden    = errors.New("pushpad: forbidden")
+	ErrNotFound     = errors.New("pushpad: not found")
+	ErrValidation   = errors.New("pushpad: validation error")
+	ErrRateLimited  = errors.New("pushpad: rate limited")
+	ErrServer       = errors.New("pushpad: server error")
+)
+
+// APIError represents a non-2xx API response.
+type APIError struct {
+	StatusCode int
+	Body       string
+
+	// Message is the error message returned by the API, if any.
+	Message string
+	// Errors holds the validation messages for each field, if any.
+	Errors map[string][]string
+
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// RequestID is the ID assigned to the request by Pushpad, useful when contacting support.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("pushpad: ")
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	if e.Body == "" {
+		fmt.Fprintf(&b, "unexpected status code %d", e.StatusCode)
+	} else {
+		fmt.Fprintf(&b, "status %d: %s", e.StatusCode, e.Body)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// FieldErrors returns the validation messages as "field message" strings, sorted by field.
+func (e *APIError) FieldErrors() []string {
+	return fieldErrors(e.Errors)
+}
+
+func fieldErrors(errs map[string][]string) []string {
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var messages []string
+	for _, field := range fields {
+		for _, message := range errs[field] {
+			messages = append(messages, field+" "+message)
+		}
+	}
+	return messages
+}
+
+func newAPIError(method, path string, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       string(body),
+		Method:     method,
+		Path:       path,
+		RequestID:  res.Header.Get("X-Request-Id"),
+	}
+
+	var parsed struct {
+		Error  json.RawMessage `json:"error"`
+		Errors json.RawMessage `json:"errors"`
+	}
+	if json.Unmarshal(body, &parsed) != nil {
+		return apiErr
+	}
+	var message string
+	if json.Unmarshal(parsed.Error, &message) == nil {
+		apiErr.Message = message
+	}
+	apiErr.Errors = parseFieldErrors(parsed.Errors)
+	return apiErr
+}
+
+// parseFieldErrors accepts {"field": ["message"]}, {"field": "message"} and
+// ["message"] (stored under the "base" field).
+func parseFieldErrors(raw json.RawMessage) map[string][]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(raw, &fields) == nil {
+		errs := map[string][]string{}
+		for field, value := range fields {
+			var messages []string
+			var message string
+			if json.Unmarshal(value, &messages) == nil {
+				errs[field] = messages
+			} else if json.Unmarshal(value, &message) == nil {
+				errs[field] = []string{message}
+			}
+		}
+		return errs
+	}
+	var messages []string
+	if json.Unmarshal(raw, &messages) == nil && len(messages) > 0 {
+		return map[string][]string{"base": messages}
+	}
+	return nil
+}
+
+// ValidationError lists the problems found by client-side validation, such as
+// NotificationCreateParams.Validate, before a request is sent. It matches
+// ErrValidation with errors.Is, like the validation errors returned by the API.
+type ValidationError struct {
+	// Errors holds the validation messages for each field.
+	Errors map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	return "pushpad: validation failed: " + strings.Join(e.FieldErrors(), ", ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Add records a validation message for a field.
+func (e *ValidationError) Add(field, message string) {
+	if e.Errors == nil {
+		e.Errors = map[string][]string{}
+	}
+	e.Errors[field] = append(e.Errors[field], message)
+}
+
+// FieldErrors returns the validation messages as "field message" strings, sorted by field.
+func (e *ValidationError) FieldErrors() []string {
+	return fieldErrors(e.Errors)
+}
+
+// Err returns e if it holds any message, and nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
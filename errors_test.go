@@ -0,0 +1,103 @@
+package pushpad
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(422)
+		w.Write([]byte(`{"error":"validation error","errors":{"body":["can't be blank"],"target_url":"is invalid"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL))
+	_, err := c.DoRequest("POST", "/projects/123/notifications", nil, nil, []int{201}, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected error to match ErrValidation")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error not to match ErrNotFound")
+	}
+	if apiErr.Message != "validation error" {
+		t.Errorf("expected message validation error, got %q", apiErr.Message)
+	}
+	if apiErr.Method != "POST" || apiErr.Path != "/projects/123/notifications" {
+		t.Errorf("expected POST /projects/123/notifications, got %s %s", apiErr.Method, apiErr.Path)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("expected request ID req-123, got %q", apiErr.RequestID)
+	}
+	fieldErrors := apiErr.FieldErrors()
+	if len(fieldErrors) != 2 || fieldErrors[0] != "body can't be blank" || fieldErrors[1] != "target_url is invalid" {
+		t.Errorf("unexpected field errors %v", fieldErrors)
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{400, ErrBadRequest},
+		{401, ErrUnauthorized},
+		{403, ErrForbidden},
+		{404, ErrNotFound},
+		{422, ErrValidation},
+		{429, ErrRateLimited},
+		{500, ErrServer},
+		{503, ErrServer},
+	}
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		if !errors.Is(err, tt.target) {
+			t.Errorf("expected status %d to match %s", tt.status, tt.target)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{StatusCode: 404, Body: `{"error":"not found"}`, Method: "GET", Path: "/notifications/1", RequestID: "abc"}
+	want := `pushpad: GET /notifications/1: status 404: {"error":"not found"} (request ID abc)`
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+
+	err = &APIError{StatusCode: 500}
+	if err.Error() != "pushpad: unexpected status code 500" {
+		t.Errorf("got %q", err.Error())
+	}
+}
+
+func TestAPIErrorWithBaseErrors(t *testing.T) {
+	apiErr := newAPIError("POST", "/senders", &http.Response{StatusCode: 422, Header: http.Header{}}, []byte(`{"errors":["Name is missing"]}`))
+	if len(apiErr.Errors["base"]) != 1 || apiErr.Errors["base"][0] != "Name is missing" {
+		t.Errorf("unexpected errors %v", apiErr.Errors)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	errs := &ValidationError{}
+	if errs.Err() != nil {
+		t.Errorf("expected no error without messages")
+	}
+	errs.Add("ttl", "must be positive")
+	errs.Add("body", "can't be blank")
+
+	err := errs.Err()
+	if !errors.Is(err, ErrValidation) || errors.Is(err, ErrBadRequest) {
+		t.Errorf("expected only ErrValidation to match, got %v", err)
+	}
+	if err.Error() != "pushpad: validation failed: body can't be blank, ttl must be positive" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
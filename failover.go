@@ -0,0 +1,78 @@
+package pushpad
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFailoverCooldown is how long a failing base URL is skipped.
+const DefaultFailoverCooldown = 30 * time.Second
+
+// WithBaseURLs sets an ordered list of base URLs. Requests are sent to the
+// first healthy base URL and fail over to the next one after a connection
+// error or a 5xx response. A base URL that fails is skipped for the failover
+// cooldown. POST and PATCH requests fail over only when it is safe to repeat
+// them, following the same rules as the retry policy.
+func WithBaseURLs(baseURLs ...string) ClientOption {
+	return func(c *Client) {
+		if len(baseURLs) > 0 {
+			c.baseURLs = append([]string(nil), baseURLs...)
+		}
+	}
+}
+
+// WithFailoverCooldown sets how long a failing base URL is skipped
+// (defaults to DefaultFailoverCooldown).
+func WithFailoverCooldown(cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		c.health.cooldown = cooldown
+	}
+}
+
+// endpointHealth tracks the base URLs that recently failed.
+type endpointHealth struct {
+	mu             sync.Mutex
+	cooldown       time.Duration
+	unhealthyUntil map[string]time.Time
+}
+
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{cooldown: DefaultFailoverCooldown, unhealthyUntil: map[string]time.Time{}}
+}
+
+// order returns the healthy base URLs in their configured order, followed by
+// the unhealthy ones, from the one that recovers first.
+func (h *endpointHealth) order(baseURLs []string) []string {
+	if len(baseURLs) < 2 {
+		return baseURLs
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []string
+	for _, baseURL := range baseURLs {
+		if until, ok := h.unhealthyUntil[baseURL]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, baseURL)
+		} else {
+			healthy = append(healthy, baseURL)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return h.unhealthyUntil[unhealthy[i]].Before(h.unhealthyUntil[unhealthy[j]])
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (h *endpointHealth) markFailed(baseURL string) {
+	h.mu.Lock()
+	h.unhealthyUntil[baseURL] = time.Now().Add(h.cooldown)
+	h.mu.Unlock()
+}
+
+func (h *endpointHealth) markHealthy(baseURL string) {
+	h.mu.Lock()
+	delete(h.unhealthyUntil, baseURL)
+	h.mu.Unlock()
+}
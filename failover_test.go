@@ -0,0 +1,90 @@
+package pushpad
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFailoverOnServerError(t *testing.T) {
+	var primaryHits, secondaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits.Add(1)
+		w.WriteHeader(502)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondaryHits.Add(1)
+		w.Write([]byte(`[]`))
+	}))
+	defer secondary.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURLs(primary.URL, secondary.URL), WithFailoverCooldown(time.Minute))
+	for i := 0; i < 2; i++ {
+		if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+	if primaryHits.Load() != 1 {
+		t.Errorf("expected the failing base URL to be skipped during the cooldown, got %d hits", primaryHits.Load())
+	}
+	if secondaryHits.Load() != 2 {
+		t.Errorf("expected 2 hits on the secondary base URL, got %d", secondaryHits.Load())
+	}
+}
+
+func TestFailoverOnConnectionError(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downURL := down.URL
+	down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(201)
+	}))
+	defer up.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURLs(downURL, up.URL))
+	if _, err := c.DoRequest("POST", "/projects", nil, map[string]string{"name": "x"}, []int{201}, nil); err != nil {
+		t.Fatalf("expected the POST to fail over after a dial error, got %s", err)
+	}
+}
+
+func TestNoFailoverForPostAfterServerError(t *testing.T) {
+	var secondaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondaryHits.Add(1)
+		w.WriteHeader(201)
+	}))
+	defer secondary.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURLs(primary.URL, secondary.URL))
+	if _, err := c.DoRequest("POST", "/projects/1/notifications", nil, nil, []int{201}, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if secondaryHits.Load() != 0 {
+		t.Errorf("expected the POST not to be repeated on the secondary base URL")
+	}
+}
+
+func TestEndpointHealthOrder(t *testing.T) {
+	h := newEndpointHealth()
+	h.markFailed("a")
+	h.markFailed("b")
+
+	got := h.order([]string{"a", "b", "c"})
+	if len(got) != 3 || got[0] != "c" || got[1] != "a" || got[2] != "b" {
+		t.Errorf("unexpected order %v", got)
+	}
+
+	h.markHealthy("a")
+	got = h.order([]string{"a", "b", "c"})
+	if got[0] != "a" || got[1] != "c" || got[2] != "b" {
+		t.Errorf("unexpected order %v", got)
+	}
+}
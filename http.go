@@ -4,90 +4,189 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const DefaultBaseURL = "https://pushpad.xyz/api/v1"
 
-// APIError represents a non-2xx API response.
-type APIError struct {
-	StatusCode int
-	Body       string
+// ResolveProjectID returns the provided project ID or the configured default project ID.
+func ResolveProjectID(projectID *int64) (int64, error) {
+	return DefaultClient().ResolveProjectID(projectID)
 }
 
-func (e *APIError) Error() string {
-	if e.Body == "" {
-		return fmt.Sprintf("pushpad: unexpected status code %d", e.StatusCode)
-	}
-	return fmt.Sprintf("pushpad: status %d: %s", e.StatusCode, e.Body)
+// DoRequest performs an HTTP request against the Pushpad API using the default client.
+func DoRequest(method, path string, query url.Values, body any, okStatuses []int, out any, opts ...RequestOption) (*http.Response, error) {
+	return DefaultClient().DoRequest(method, path, query, body, okStatuses, out, opts...)
 }
 
-// ResolveProjectID returns the provided project ID or the configured default project ID.
-func ResolveProjectID(projectID *int64) (int64, error) {
-	if projectID != nil && *projectID != 0 {
-		return *projectID, nil
+// DoRequestWithContext is like DoRequest but carries ctx to the HTTP request.
+func DoRequestWithContext(ctx context.Context, method, path string, query url.Values, body any, okStatuses []int, out any, opts ...RequestOption) (*http.Response, error) {
+	return DefaultClient().DoRequestWithContext(ctx, method, path, query, body, okStatuses, out, opts...)
+}
+
+// DoRequest performs an HTTP request against the Pushpad API.
+func (c *Client) DoRequest(method, path string, query url.Values, body any, okStatuses []int, out any, opts ...RequestOption) (*http.Response, error) {
+	return c.DoRequestWithContext(context.Background(), method, path, query, body, okStatuses, out, opts...)
+}
+
+// DoRequestWithContext performs an HTTP request against the Pushpad API.
+// The request is cancelled when ctx is done. Failed attempts are retried
+// according to the retry policy of the client.
+func (c *Client) DoRequestWithContext(ctx context.Context, method, path string, query url.Values, body any, okStatuses []int, out any, opts ...RequestOption) (*http.Response, error) {
+	o := newRequestOptions(opts)
+	if len(c.instrumentation) == 0 {
+		res, bodyBytes, err := c.roundTrip(ctx, method, path, query, body, okStatuses, o)
+		if err != nil {
+			return nil, err
+		}
+		return checkResponse(method, path, res, bodyBytes, okStatuses, out)
+	}
+
+	info := newRequestInfo(method, path, o)
+	for _, instrumentation := range c.instrumentation {
+		ctx = instrumentation.RequestStart(ctx, info)
+	}
+	start := time.Now()
+	res, bodyBytes, err := c.roundTrip(ctx, method, path, query, body, okStatuses, o)
+	if err == nil {
+		res, err = checkResponse(method, path, res, bodyBytes, okStatuses, out)
 	}
-	if pushpadProjectID == 0 {
-		return 0, fmt.Errorf("pushpad: project ID is required")
+	result := RequestResult{
+		Err:           err,
+		Attempts:      o.attempts,
+		RequestBytes:  o.requestBytes,
+		ResponseBytes: int64(len(bodyBytes)),
+		Duration:      time.Since(start),
 	}
-	return pushpadProjectID, nil
+	if res != nil {
+		result.StatusCode = res.StatusCode
+	}
+	for i := len(c.instrumentation) - 1; i >= 0; i-- {
+		c.instrumentation[i].RequestEnd(ctx, info, result)
+	}
+	return res, err
 }
 
-// DoRequest performs an HTTP request against the Pushpad API.
-func DoRequest(method, path string, query url.Values, body any, okStatuses []int, out any) (*http.Response, error) {
-	ctx := context.Background()
-	baseURL := strings.TrimRight(DefaultBaseURL, "/")
-	endpoint := baseURL + path
+// roundTrip sends the request and returns the response with its body, which
+// may have a status that is not OK. Requests with an idempotency key return
+// the stored response when they have already succeeded.
+func (c *Client) roundTrip(ctx context.Context, method, path string, query url.Values, body any, okStatuses []int, o *requestOptions) (*http.Response, []byte, error) {
+	target := path
 	if len(query) > 0 {
-		endpoint += "?" + query.Encode()
+		target += "?" + query.Encode()
 	}
 
-	var bodyReader io.Reader
+	var payload []byte
 	if body != nil {
-		payload, err := json.Marshal(body)
+		var err error
+		payload, err = json.Marshal(body)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		bodyReader = bytes.NewBuffer(payload)
 	}
+	o.requestBytes = int64(len(payload))
 
-	req, err := http.NewRequestWithContext(ctx, method, endpoint, bodyReader)
-	if err != nil {
-		return nil, err
+	var idempotencyKey string
+	var entry *idempotencyEntry
+	if o.idempotencyKey != "" {
+		o.header.Set(IdempotencyKeyHeader, o.idempotencyKey)
+
+		var owner bool
+		var err error
+		idempotencyKey = method + " " + target + " " + o.idempotencyKey
+		entry, owner, err = c.idempotency.begin(ctx, idempotencyKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !owner {
+			return entry.response(), entry.body, nil
+		}
 	}
-	req.Header.Set("Accept", "application/json")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+
+	res, bodyBytes, err := c.do(ctx, method, target, payload, o)
+	if entry != nil {
+		if err == nil && isOK(res, okStatuses) {
+			c.idempotency.finish(idempotencyKey, entry, res, bodyBytes)
+		} else {
+			c.idempotency.finish(idempotencyKey, entry, nil, nil)
+		}
 	}
-	if pushpadAuthToken != "" {
-		req.Header.Set("Authorization", "Bearer "+pushpadAuthToken)
+	return res, bodyBytes, err
+}
+
+// do performs the request, retrying failed attempts according to the retry policy.
+func (c *Client) do(ctx context.Context, method, target string, payload []byte, o *requestOptions) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		o.attempts = attempt
+		req, res, bodyBytes, err := c.attempt(ctx, attempt, method, target, payload, o)
+		if req == nil {
+			return nil, nil, err
+		}
+		if !c.retryPolicy.shouldRetry(attempt, req, res, err) {
+			return res, bodyBytes, err
+		}
+		delay := c.retryPolicy.backoff(attempt, res)
+		c.logRetry(ctx, method, target, attempt+1, delay)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, nil, err
+		}
 	}
+}
 
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
+// attempt sends the request to the healthy base URLs in order, failing over
+// to the next one after a connection error or a 5xx response when it is safe
+// to repeat the request. It returns the last request sent.
+func (c *Client) attempt(ctx context.Context, attempt int, method, target string, payload []byte, o *requestOptions) (*http.Request, *http.Response, []byte, error) {
+	path, _, _ := strings.Cut(target, "?")
+	baseURLs := c.health.order(c.baseURLs)
+	for i, baseURL := range baseURLs {
+		req, err := c.newRequest(ctx, method, strings.TrimRight(baseURL, "/")+target, payload, o)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if err := c.waitRateLimit(ctx, path); err != nil {
+			return nil, nil, nil, err
+		}
+		start := time.Now()
+		res, bodyBytes, err := c.send(req)
+		c.logAttempt(ctx, attempt, path, req, payload, res, bodyBytes, err, time.Since(start))
+		if ctx.Err() != nil || (err == nil && res.StatusCode < 500) {
+			if err == nil {
+				c.health.markHealthy(baseURL)
+			}
+			return req, res, bodyBytes, err
+		}
+
+		c.health.markFailed(baseURL)
+		if i == len(baseURLs)-1 || !c.retryPolicy.canRepeat(req, err) {
+			return req, res, bodyBytes, err
+		}
 	}
-	defer res.Body.Close()
+	return nil, nil, nil, errors.New("pushpad: no base URL configured")
+}
 
-	ok := false
+func isOK(res *http.Response, okStatuses []int) bool {
 	for _, code := range okStatuses {
 		if res.StatusCode == code {
-			ok = true
-			break
+			return true
 		}
 	}
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	if !ok {
-		return res, &APIError{StatusCode: res.StatusCode, Body: string(bodyBytes)}
+	return false
+}
+
+func checkResponse(method, path string, res *http.Response, bodyBytes []byte, okStatuses []int, out any) (*http.Response, error) {
+	if !isOK(res, okStatuses) {
+		return res, newAPIError(method, path, res, bodyBytes)
 	}
+	return decodeResponse(res, bodyBytes, out)
+}
 
+func decodeResponse(res *http.Response, bodyBytes []byte, out any) (*http.Response, error) {
 	if out != nil && len(bodyBytes) > 0 {
 		if err := json.Unmarshal(bodyBytes, out); err != nil {
 			return res, err
@@ -96,3 +195,45 @@ func DoRequest(method, path string, query url.Values, body any, okStatuses []int
 
 	return res, nil
 }
+
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, payload []byte, o *requestOptions) (*http.Request, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	authToken, err := c.currentAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+	for key, values := range o.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	return req, nil
+}
+
+// send performs a single attempt and returns the response with its body already read.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	res, err := c.handler()(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, bodyBytes, nil
+}
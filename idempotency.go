@@ -0,0 +1,109 @@
+package pushpad
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header that carries the idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL is how long the response to a request with an
+// idempotency key is remembered by the client.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// WithIdempotencyTTL sets how long the responses to requests with an
+// idempotency key are remembered (defaults to DefaultIdempotencyTTL).
+func WithIdempotencyTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.idempotency.ttl = ttl
+	}
+}
+
+// NewIdempotencyKey returns a random key suitable for an idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// idempotencyStore remembers the successful responses to requests sent with an
+// idempotency key, so that repeating the request returns the original response
+// instead of performing it again.
+type idempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	done    chan struct{}
+	res     *http.Response
+	body    []byte
+	expires time.Time
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{ttl: DefaultIdempotencyTTL, entries: map[string]*idempotencyEntry{}}
+}
+
+// begin returns the stored entry for key if the request already succeeded.
+// Otherwise it reserves key for the caller, who must call finish. Concurrent
+// requests with the same key wait for the reserved one to finish.
+func (s *idempotencyStore) begin(ctx context.Context, key string) (entry *idempotencyEntry, owner bool, err error) {
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		for k, e := range s.entries {
+			if e.res != nil && now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		entry, found := s.entries[key]
+		if !found {
+			entry = &idempotencyEntry{done: make(chan struct{})}
+			s.entries[key] = entry
+			s.mu.Unlock()
+			return entry, true, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-entry.done:
+			if entry.res != nil {
+				return entry, false, nil
+			}
+			// the previous request failed: try to reserve the key again
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+// finish stores the response of a successful request, or releases the key
+// so that the request can be performed again.
+func (s *idempotencyStore) finish(key string, entry *idempotencyEntry, res *http.Response, body []byte) {
+	s.mu.Lock()
+	if res != nil {
+		stored := *res
+		stored.Header = res.Header.Clone()
+		entry.res = &stored
+		entry.body = body
+		entry.expires = time.Now().Add(s.ttl)
+	} else {
+		delete(s.entries, key)
+	}
+	s.mu.Unlock()
+	close(entry.done)
+}
+
+// response returns a copy of the stored response that callers can modify.
+func (e *idempotencyEntry) response() *http.Response {
+	res := *e.res
+	res.Header = e.res.Header.Clone()
+	return &res
+}
@@ -0,0 +1,92 @@
+package pushpad
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIdempotencyKeyReturnsOriginalResponse(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(IdempotencyKeyHeader) != "KEY1" {
+			t.Errorf("expected idempotency key header, got %q", r.Header.Get(IdempotencyKeyHeader))
+		}
+		attempts.Add(1)
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":42}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL))
+	for i := 0; i < 2; i++ {
+		var out struct{ ID int64 }
+		res, err := c.DoRequest("POST", "/projects/123/notifications", nil, map[string]string{"body": "Hi"}, []int{201}, &out, WithIdempotencyKey("KEY1"))
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		if res.StatusCode != 201 {
+			t.Errorf("expected status 201, got %d", res.StatusCode)
+		}
+		if out.ID != 42 {
+			t.Errorf("expected ID 42, got %d", out.ID)
+		}
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", attempts.Load())
+	}
+}
+
+func TestIdempotencyKeyIsReleasedAfterFailure(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL))
+	if _, err := c.DoRequest("POST", "/projects/123/notifications", nil, nil, []int{201}, nil, WithIdempotencyKey("KEY1")); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if _, err := c.DoRequest("POST", "/projects/123/notifications", nil, nil, []int{201}, nil, WithIdempotencyKey("KEY1")); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", attempts.Load())
+	}
+}
+
+func TestIdempotencyKeyMakesPostRetryable(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	if _, err := c.DoRequest("POST", "/projects/123/notifications", nil, nil, []int{201}, nil, WithIdempotencyKey("KEY1")); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	a, b := NewIdempotencyKey(), NewIdempotencyKey()
+	if len(a) != 32 {
+		t.Errorf("expected a 32 characters key, got %q", a)
+	}
+	if a == b {
+		t.Errorf("expected different keys, got %q twice", a)
+	}
+}
@@ -0,0 +1,62 @@
+package pushpad
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Instrumentation receives callbacks around every API call, for tracing and
+// metrics. A call includes all its attempts. Implementations must be safe for
+// concurrent use.
+type Instrumentation interface {
+	// RequestStart is called before the call. The returned context is used
+	// for the call, so that it can carry a span.
+	RequestStart(ctx context.Context, info RequestInfo) context.Context
+	// RequestEnd is called after the call, with the context returned by RequestStart.
+	RequestEnd(ctx context.Context, info RequestInfo, result RequestResult)
+}
+
+// RequestInfo describes an API call.
+type RequestInfo struct {
+	// Operation is the name of the operation, such as "notification.Create",
+	// or the HTTP method when the request has no operation name.
+	Operation string
+	Method    string
+	Path      string
+	// ProjectID is the project in the path of the request, or 0 when the
+	// endpoint is not scoped to a project.
+	ProjectID int64
+}
+
+// RequestResult describes the outcome of an API call.
+type RequestResult struct {
+	// StatusCode is the status of the last response, or 0 when no response was received.
+	StatusCode    int
+	Err           error
+	Attempts      int
+	RequestBytes  int64
+	ResponseBytes int64
+	Duration      time.Duration
+}
+
+// WithInstrumentation adds instrumentation to the client. Start callbacks are
+// called in order and end callbacks in reverse order.
+func WithInstrumentation(instrumentation ...Instrumentation) ClientOption {
+	return func(c *Client) {
+		c.instrumentation = append(c.instrumentation, instrumentation...)
+	}
+}
+
+func newRequestInfo(method, path string, o *requestOptions) RequestInfo {
+	info := RequestInfo{Operation: o.operation, Method: method, Path: path}
+	if info.Operation == "" {
+		info.Operation = method
+	}
+	if rest, ok := strings.CutPrefix(path, "/projects/"); ok {
+		id, _, _ := strings.Cut(rest, "/")
+		info.ProjectID, _ = strconv.ParseInt(id, 10, 64)
+	}
+	return info
+}
@@ -0,0 +1,91 @@
+package pushpad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ctxKey struct{}
+
+type recordingInstrumentation struct {
+	name   string
+	events *[]string
+	info   RequestInfo
+	result RequestResult
+	ctx    context.Context
+}
+
+func (r *recordingInstrumentation) RequestStart(ctx context.Context, info RequestInfo) context.Context {
+	*r.events = append(*r.events, "start "+r.name)
+	return context.WithValue(ctx, ctxKey{}, r.name)
+}
+
+func (r *recordingInstrumentation) RequestEnd(ctx context.Context, info RequestInfo, result RequestResult) {
+	*r.events = append(*r.events, "end "+r.name)
+	r.info = info
+	r.result = result
+	r.ctx = ctx
+}
+
+func TestWithInstrumentation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(ctxKey{}) != nil {
+			t.Errorf("unexpected context value in server")
+		}
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var events []string
+	first := &recordingInstrumentation{name: "first", events: &events}
+	second := &recordingInstrumentation{name: "second", events: &events}
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithInstrumentation(first, second))
+
+	_, err := c.DoRequest("POST", "/projects/123/notifications", nil, map[string]string{"body": "Hi"}, []int{201}, nil, WithOperation("notification.Create"))
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if want := []string{"start first", "start second", "end second", "end first"}; len(events) != len(want) || events[0] != want[0] || events[1] != want[1] || events[2] != want[2] || events[3] != want[3] {
+		t.Errorf("unexpected callback order %v", events)
+	}
+	if first.ctx.Value(ctxKey{}) != "second" {
+		t.Errorf("expected the context returned by the start callbacks")
+	}
+	want := RequestInfo{Operation: "notification.Create", Method: "POST", Path: "/projects/123/notifications", ProjectID: 123}
+	if first.info != want {
+		t.Errorf("unexpected info %+v", first.info)
+	}
+	result := first.result
+	if result.StatusCode != 201 || result.Err != nil || result.Attempts != 1 || result.RequestBytes != 13 || result.ResponseBytes != 8 || result.Duration <= 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestInstrumentationError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(404)
+	}))
+	defer server.Close()
+
+	var events []string
+	recorder := &recordingInstrumentation{events: &events}
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithInstrumentation(recorder))
+
+	_, err := c.DoRequest("GET", "/senders/5", nil, nil, []int{200}, nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if recorder.info.Operation != "GET" || recorder.info.ProjectID != 0 {
+		t.Errorf("unexpected info %+v", recorder.info)
+	}
+	if recorder.result.StatusCode != 404 || !errors.Is(recorder.result.Err, ErrNotFound) || recorder.result.Attempts != 1 {
+		t.Errorf("unexpected result %+v", recorder.result)
+	}
+}
@@ -0,0 +1,134 @@
+package pushpad
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields lists the JSON fields that are never logged.
+var sensitiveFields = map[string]bool{
+	"vapid_private_key": true,
+}
+
+// WithLogger logs every request attempt with the method, path, status,
+// latency, attempt number and Pushpad request ID. Successful requests are
+// logged at the debug level, failures and retries at the warn level.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDebugBodies also logs the headers and bodies of requests and responses,
+// at the debug level. The Authorization header and the VAPID private keys of
+// senders are redacted.
+func WithDebugBodies() ClientOption {
+	return func(c *Client) {
+		c.debugBodies = true
+	}
+}
+
+func (c *Client) logAttempt(ctx context.Context, attempt int, path string, req *http.Request, payload []byte, res *http.Response, body []byte, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if requestID := res.Header.Get("X-Request-Id"); requestID != "" {
+			attrs = append(attrs, slog.String("request_id", requestID))
+		}
+		if res.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+	}
+	c.logger.LogAttrs(ctx, level, "pushpad request", attrs...)
+
+	if c.debugBodies {
+		debugAttrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Any("request_headers", redactHeader(req.Header)),
+			slog.String("request_body", string(redactJSON(payload))),
+		}
+		if res != nil {
+			debugAttrs = append(debugAttrs,
+				slog.Any("response_headers", res.Header),
+				slog.String("response_body", string(redactJSON(body))),
+			)
+		}
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "pushpad request body", debugAttrs...)
+	}
+}
+
+func (c *Client) logRetry(ctx context.Context, method, target string, attempt int, delay time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	path, _, _ := strings.Cut(target, "?")
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "pushpad retry",
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+	)
+}
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", redacted)
+	}
+	return header
+}
+
+// redactJSON replaces the values of the sensitive fields of a JSON body.
+// Bodies that are not JSON are returned unchanged.
+func redactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var value any
+	if json.Unmarshal(body, &value) != nil {
+		return body
+	}
+	redactValue(value)
+	out, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func redactValue(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFields[key] {
+				v[key] = redacted
+			} else {
+				redactValue(field)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package pushpad
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"vapid_private_key":"SECRET_KEY"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewClient("SECRET_TOKEN", 123, WithBaseURL(server.URL), WithLogger(logger), WithDebugBodies())
+	body := map[string]string{"name": "Sender", "vapid_private_key": "SECRET_KEY"}
+	if _, err := c.DoRequest("POST", "/senders", nil, body, []int{201}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "SECRET_TOKEN") || strings.Contains(output, "SECRET_KEY") {
+		t.Errorf("expected secrets to be redacted, got %s", output)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), output)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid log line: %s", err)
+	}
+	if entry["msg"] != "pushpad request" || entry["method"] != "POST" || entry["path"] != "/senders" || entry["status"] != float64(201) || entry["request_id"] != "req-1" || entry["attempt"] != float64(1) {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+	if _, ok := entry["latency"]; !ok {
+		t.Errorf("expected latency in log entry %v", entry)
+	}
+	if !strings.Contains(lines[1], `[REDACTED]`) || !strings.Contains(lines[1], `\"name\":\"Sender\"`) {
+		t.Errorf("expected the redacted bodies, got %s", lines[1])
+	}
+}
+
+func TestLoggerRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithLogger(logger), WithRetryPolicy(policy))
+	if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "level=WARN msg=\"pushpad request\"") || !strings.Contains(output, "status=503") {
+		t.Errorf("expected the failed attempt to be logged, got %s", output)
+	}
+	if !strings.Contains(output, "msg=\"pushpad retry\" method=GET path=/projects attempt=2") {
+		t.Errorf("expected the retry to be logged, got %s", output)
+	}
+	if strings.Contains(output, "request_body") {
+		t.Errorf("expected no bodies without debug mode, got %s", output)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	got := string(redactJSON([]byte(`[{"id":1,"vapid_private_key":"KEY","vapid_public_key":"PUB"}]`)))
+	want := `[{"id":1,"vapid_private_key":"[REDACTED]","vapid_public_key":"PUB"}]`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got := string(redactJSON([]byte("not json"))); got != "not json" {
+		t.Errorf("expected non JSON bodies to be unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package metrics collects request metrics from a Pushpad client and exposes
+// them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histogram
+// buckets used when NewCollector is called without buckets.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector is a pushpad.Instrumentation that counts requests and records
+// their latency per operation. It is safe for concurrent use.
+//
+//	collector := metrics.NewCollector()
+//	client := pushpad.NewClient(token, projectID, pushpad.WithInstrumentation(collector))
+//	http.Handle("/metrics", collector)
+type Collector struct {
+	buckets []float64
+
+	mu         sync.Mutex
+	requests   map[requestKey]uint64
+	operations map[string]*operationStats
+}
+
+type requestKey struct {
+	operation string
+	status    string
+}
+
+type operationStats struct {
+	buckets       []uint64
+	count         uint64
+	sum           float64
+	requestBytes  int64
+	responseBytes int64
+}
+
+var _ pushpad.Instrumentation = (*Collector)(nil)
+
+// NewCollector returns a collector with the given histogram buckets, in
+// seconds, or DefaultBuckets when none are given.
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &Collector{
+		buckets:    slices.Compact(buckets),
+		requests:   map[requestKey]uint64{},
+		operations: map[string]*operationStats{},
+	}
+}
+
+// RequestStart implements pushpad.Instrumentation.
+func (c *Collector) RequestStart(ctx context.Context, info pushpad.RequestInfo) context.Context {
+	return ctx
+}
+
+// RequestEnd implements pushpad.Instrumentation.
+func (c *Collector) RequestEnd(ctx context.Context, info pushpad.RequestInfo, result pushpad.RequestResult) {
+	status := "error"
+	if result.StatusCode != 0 {
+		status = strconv.Itoa(result.StatusCode)
+	}
+	seconds := result.Duration.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests[requestKey{info.Operation, status}]++
+
+	stats := c.operations[info.Operation]
+	if stats == nil {
+		stats = &operationStats{buckets: make([]uint64, len(c.buckets))}
+		c.operations[info.Operation] = stats
+	}
+	for i, bound := range c.buckets {
+		if seconds <= bound {
+			stats.buckets[i]++
+		}
+	}
+	stats.count++
+	stats.sum += seconds
+	stats.requestBytes += result.RequestBytes
+	stats.responseBytes += result.ResponseBytes
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	c.mu.Lock()
+	b.WriteString("# HELP pushpad_requests_total Total number of Pushpad API requests.\n")
+	b.WriteString("# TYPE pushpad_requests_total counter\n")
+	keys := slices.SortedFunc(maps.Keys(c.requests), func(a, b requestKey) int {
+		if a.operation != b.operation {
+			return strings.Compare(a.operation, b.operation)
+		}
+		return strings.Compare(a.status, b.status)
+	})
+	for _, key := range keys {
+		fmt.Fprintf(&b, "pushpad_requests_total{operation=%s,status=%s} %d\n", label(key.operation), label(key.status), c.requests[key])
+	}
+
+	operations := slices.Sorted(maps.Keys(c.operations))
+	b.WriteString("# HELP pushpad_request_duration_seconds Latency of Pushpad API requests, including retries.\n")
+	b.WriteString("# TYPE pushpad_request_duration_seconds histogram\n")
+	for _, operation := range operations {
+		stats := c.operations[operation]
+		for i, bound := range c.buckets {
+			fmt.Fprintf(&b, "pushpad_request_duration_seconds_bucket{operation=%s,le=%s} %d\n", label(operation), label(formatFloat(bound)), stats.buckets[i])
+		}
+		fmt.Fprintf(&b, "pushpad_request_duration_seconds_bucket{operation=%s,le=\"+Inf\"} %d\n", label(operation), stats.count)
+		fmt.Fprintf(&b, "pushpad_request_duration_seconds_sum{operation=%s} %s\n", label(operation), formatFloat(stats.sum))
+		fmt.Fprintf(&b, "pushpad_request_duration_seconds_count{operation=%s} %d\n", label(operation), stats.count)
+	}
+
+	b.WriteString("# HELP pushpad_request_bytes_total Total size of Pushpad API request bodies.\n")
+	b.WriteString("# TYPE pushpad_request_bytes_total counter\n")
+	for _, operation := range operations {
+		fmt.Fprintf(&b, "pushpad_request_bytes_total{operation=%s} %d\n", label(operation), c.operations[operation].requestBytes)
+	}
+	b.WriteString("# HELP pushpad_response_bytes_total Total size of Pushpad API response bodies.\n")
+	b.WriteString("# TYPE pushpad_response_bytes_total counter\n")
+	for _, operation := range operations {
+		fmt.Fprintf(&b, "pushpad_response_bytes_total{operation=%s} %d\n", label(operation), c.operations[operation].responseBytes)
+	}
+	c.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func label(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
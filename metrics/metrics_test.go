@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(0.1, 1)
+	ctx := context.Background()
+
+	create := pushpad.RequestInfo{Operation: "notification.Create", Method: "POST", Path: "/projects/1/notifications", ProjectID: 1}
+	c.RequestEnd(c.RequestStart(ctx, create), create, pushpad.RequestResult{StatusCode: 201, RequestBytes: 20, ResponseBytes: 30, Duration: 50 * time.Millisecond})
+	c.RequestEnd(ctx, create, pushpad.RequestResult{StatusCode: 201, RequestBytes: 20, ResponseBytes: 30, Duration: 500 * time.Millisecond})
+	list := pushpad.RequestInfo{Operation: `sub"list`, Method: "GET"}
+	c.RequestEnd(ctx, list, pushpad.RequestResult{Err: errors.New("timeout"), Duration: 2 * time.Second})
+
+	var b strings.Builder
+	if _, err := c.WriteTo(&b); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	output := b.String()
+
+	for _, line := range []string{
+		`# TYPE pushpad_requests_total counter`,
+		`pushpad_requests_total{operation="notification.Create",status="201"} 2`,
+		`pushpad_requests_total{operation="sub\"list",status="error"} 1`,
+		`# TYPE pushpad_request_duration_seconds histogram`,
+		`pushpad_request_duration_seconds_bucket{operation="notification.Create",le="0.1"} 1`,
+		`pushpad_request_duration_seconds_bucket{operation="notification.Create",le="1"} 2`,
+		`pushpad_request_duration_seconds_bucket{operation="notification.Create",le="+Inf"} 2`,
+		`pushpad_request_duration_seconds_sum{operation="notification.Create"} 0.55`,
+		`pushpad_request_duration_seconds_count{operation="notification.Create"} 2`,
+		`pushpad_request_duration_seconds_bucket{operation="sub\"list",le="1"} 0`,
+		`pushpad_request_duration_seconds_bucket{operation="sub\"list",le="+Inf"} 1`,
+		`pushpad_request_bytes_total{operation="notification.Create"} 40`,
+		`pushpad_response_bytes_total{operation="notification.Create"} 60`,
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("expected line %q in output:\n%s", line, output)
+		}
+	}
+}
+
+func TestCollectorServeHTTP(t *testing.T) {
+	c := NewCollector()
+	info := pushpad.RequestInfo{Operation: "project.Get"}
+	c.RequestEnd(context.Background(), info, pushpad.RequestResult{StatusCode: 200})
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `pushpad_requests_total{operation="project.Get",status="200"} 1`) {
+		t.Errorf("unexpected body %s", rec.Body.String())
+	}
+}
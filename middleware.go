@@ -0,0 +1,36 @@
+package pushpad
+
+import "net/http"
+
+// Handler performs a single attempt of an API request.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware intercepts the requests and responses of a client.
+// It is called for each attempt, after the default headers have been set,
+// and can modify the request, the response or replace the call entirely.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the client. The first middleware is
+// the outermost one, so it sees the request first and the response last.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithTransport sets the RoundTripper used to perform requests, without
+// changing the other settings of the HTTP client.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// handler returns the chain of middleware that ends with the HTTP client.
+func (c *Client) handler() Handler {
+	h := Handler(c.client().Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
@@ -0,0 +1,80 @@
+package pushpad
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	var called bool
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		if req.URL.String() != "https://pushpad.xyz/api/v1/projects" {
+			t.Errorf("unexpected URL %s", req.URL)
+		}
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+	})
+
+	c := NewClient("TOKEN", 123, WithHTTPClient(&http.Client{Timeout: time.Second}), WithTransport(transport))
+	if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if !called {
+		t.Errorf("expected the custom transport to be used")
+	}
+	if c.client().Timeout != time.Second {
+		t.Errorf("expected the timeout of the HTTP client to be kept")
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("expected the header set by the middleware, got %q", r.Header.Get("X-Tenant"))
+		}
+		if r.Header.Get("Authorization") != "Bearer OTHER" {
+			t.Errorf("expected the authorization set by the middleware, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				res, err := next(req)
+				order = append(order, name+" response")
+				return res, err
+			}
+		}
+	}
+	headers := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Tenant", "acme")
+			req.Header.Set("Authorization", "Bearer OTHER")
+			return next(req)
+		}
+	}
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithMiddleware(trace("outer"), trace("inner")), WithMiddleware(headers))
+	if _, err := c.DoRequest("GET", "/projects", nil, nil, []int{200}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	want := []string{"outer request", "inner request", "inner response", "outer response"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", order, want)
+	}
+}
@@ -1,18 +1,111 @@
 package notification
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 
 	"github.com/pushpad/pushpad-go"
 )
 
+// Client performs notification API calls with a pushpad.Client.
+type Client struct {
+	backend *pushpad.Client
+}
+
+// NewClient returns a notification client that uses the given pushpad.Client.
+// A nil backend uses pushpad.DefaultClient().
+func NewClient(backend *pushpad.Client) *Client {
+	return &Client{backend: backend}
+}
+
+var defaultClient = &Client{}
+
+func (c *Client) api() *pushpad.Client {
+	if c.backend != nil {
+		return c.backend
+	}
+	return pushpad.DefaultClient()
+}
+
 func List(params *NotificationListParams) ([]Notification, error) {
+	return defaultClient.List(params)
+}
+
+func ListWithContext(ctx context.Context, params *NotificationListParams) ([]Notification, error) {
+	return defaultClient.ListWithContext(ctx, params)
+}
+
+// ListPage returns a page of notifications with the total count and pagination metadata.
+func ListPage(params *NotificationListParams) (*NotificationListResult, error) {
+	return defaultClient.ListPage(params)
+}
+
+func ListPageWithContext(ctx context.Context, params *NotificationListParams) (*NotificationListResult, error) {
+	return defaultClient.ListPageWithContext(ctx, params)
+}
+
+// All returns an iterator over the notifications of a project, fetching the
+// pages lazily. See Client.All.
+func All(ctx context.Context, params *NotificationAllParams) iter.Seq2[Notification, error] {
+	return defaultClient.All(ctx, params)
+}
+
+func Create(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return defaultClient.Create(params)
+}
+
+func CreateWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return defaultClient.CreateWithContext(ctx, params)
+}
+
+func Send(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return defaultClient.Send(params)
+}
+
+func SendWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return defaultClient.SendWithContext(ctx, params)
+}
+
+func Get(notificationID int64, params *NotificationGetParams) (*Notification, error) {
+	return defaultClient.Get(notificationID, params)
+}
+
+func GetWithContext(ctx context.Context, notificationID int64, params *NotificationGetParams) (*Notification, error) {
+	return defaultClient.GetWithContext(ctx, notificationID, params)
+}
+
+func Cancel(notificationID int64, params *NotificationCancelParams) error {
+	return defaultClient.Cancel(notificationID, params)
+}
+
+func CancelWithContext(ctx context.Context, notificationID int64, params *NotificationCancelParams) error {
+	return defaultClient.CancelWithContext(ctx, notificationID, params)
+}
+
+func (c *Client) List(params *NotificationListParams) ([]Notification, error) {
+	return c.ListWithContext(context.Background(), params)
+}
+
+func (c *Client) ListWithContext(ctx context.Context, params *NotificationListParams) ([]Notification, error) {
+	result, err := c.ListPageWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result.Notifications, nil
+}
+
+func (c *Client) ListPage(params *NotificationListParams) (*NotificationListResult, error) {
+	return c.ListPageWithContext(context.Background(), params)
+}
+
+func (c *Client) ListPageWithContext(ctx context.Context, params *NotificationListParams) (*NotificationListResult, error) {
 	if params == nil {
 		params = &NotificationListParams{}
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -23,47 +116,116 @@ func List(params *NotificationListParams) ([]Notification, error) {
 	}
 
 	var notifications []Notification
-	_, err = pushpad.DoRequest("GET", fmt.Sprintf("/projects/%d/notifications", projectID), query, nil, []int{200}, &notifications)
-	return notifications, err
+	res, err := c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/projects/%d/notifications", projectID), query, nil, []int{200}, &notifications, pushpad.WithOperation("notification.List"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationListResult{Notifications: notifications, ListMeta: pushpad.ParseListMeta(res)}, nil
 }
 
-func Create(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+// All returns an iterator over the notifications of a project, from the most
+// recent to the oldest, fetching the pages lazily until they are exhausted.
+// Notifications created after params.Until are skipped and the iteration stops
+// at the first notification created before params.Since, so older pages are
+// never fetched. Iteration stops at the first error, which is yielded with a
+// zero Notification.
+func (c *Client) All(ctx context.Context, params *NotificationAllParams) iter.Seq2[Notification, error] {
+	return func(yield func(Notification, error) bool) {
+		if params == nil {
+			params = &NotificationAllParams{}
+		}
+
+		for page := int64(1); ; page++ {
+			result, err := c.ListPageWithContext(ctx, &NotificationListParams{ProjectID: params.ProjectID, Page: pushpad.Int64(page)})
+			if err != nil {
+				yield(Notification{}, err)
+				return
+			}
+			if len(result.Notifications) == 0 {
+				return
+			}
+			for _, notification := range result.Notifications {
+				if params.Until != nil && notification.CreatedAt.After(*params.Until) {
+					continue
+				}
+				if params.Since != nil && notification.CreatedAt.Before(*params.Since) {
+					return
+				}
+				if !yield(notification, nil) {
+					return
+				}
+			}
+			if result.LastPage > 0 && page >= result.LastPage {
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) Create(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return c.CreateWithContext(context.Background(), params)
+}
+
+func (c *Client) CreateWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	if c.api().ValidatesParams() {
+		if err := params.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
+	opts := []pushpad.RequestOption{pushpad.WithOperation("notification.Create")}
+	if params.IdempotencyKey != nil && *params.IdempotencyKey != "" {
+		opts = append(opts, pushpad.WithIdempotencyKey(*params.IdempotencyKey))
+	}
+
 	var response NotificationCreateResponse
-	_, err = pushpad.DoRequest("POST", fmt.Sprintf("/projects/%d/notifications", projectID), nil, params, []int{201}, &response)
+	_, err = c.api().DoRequestWithContext(ctx, "POST", fmt.Sprintf("/projects/%d/notifications", projectID), nil, params, []int{201}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func Send(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
-	return Create(params)
+func (c *Client) Send(params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return c.Create(params)
 }
 
-func Get(notificationID int64, params *NotificationGetParams) (*Notification, error) {
+func (c *Client) SendWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error) {
+	return c.CreateWithContext(ctx, params)
+}
+
+func (c *Client) Get(notificationID int64, params *NotificationGetParams) (*Notification, error) {
+	return c.GetWithContext(context.Background(), notificationID, params)
+}
+
+func (c *Client) GetWithContext(ctx context.Context, notificationID int64, params *NotificationGetParams) (*Notification, error) {
 	if notificationID == 0 {
 		return nil, fmt.Errorf("pushpad: notification ID is required")
 	}
 	var notification Notification
-	_, err := pushpad.DoRequest("GET", fmt.Sprintf("/notifications/%d", notificationID), nil, nil, []int{200}, &notification)
+	_, err := c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/notifications/%d", notificationID), nil, nil, []int{200}, &notification, pushpad.WithOperation("notification.Get"))
 	if err != nil {
 		return nil, err
 	}
 	return &notification, nil
 }
 
-func Cancel(notificationID int64, params *NotificationCancelParams) error {
+func (c *Client) Cancel(notificationID int64, params *NotificationCancelParams) error {
+	return c.CancelWithContext(context.Background(), notificationID, params)
+}
+
+func (c *Client) CancelWithContext(ctx context.Context, notificationID int64, params *NotificationCancelParams) error {
 	if notificationID == 0 {
 		return fmt.Errorf("pushpad: notification ID is required")
 	}
-	_, err := pushpad.DoRequest("DELETE", fmt.Sprintf("/notifications/%d/cancel", notificationID), nil, nil, []int{204}, nil)
+	_, err := c.api().DoRequestWithContext(ctx, "DELETE", fmt.Sprintf("/notifications/%d/cancel", notificationID), nil, nil, []int{204}, nil, pushpad.WithOperation("notification.Cancel"))
 	return err
 }
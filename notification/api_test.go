@@ -1,7 +1,9 @@
 package notification
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -448,3 +450,239 @@ func TestListNotificationsMissingProjectID(t *testing.T) {
 		t.Fatalf("expected project ID required error, got %v", err)
 	}
 }
+
+func TestCreateNotificationWithContext(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Post("/api/v1/projects/123/notifications").
+		MatchHeader("Authorization", "Bearer TOKEN").
+		Reply(201).
+		BodyString(`{"id":99,"scheduled":10}`)
+
+	pushpad.Configure("TOKEN", 0)
+	response, err := CreateWithContext(context.Background(), &NotificationCreateParams{ProjectID: pushpad.Int64(123), Body: pushpad.String("Hello")})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if response.ID != 99 {
+		t.Errorf("expected notification ID 99, got %d", response.ID)
+	}
+}
+
+func TestCreateNotificationCancelledContext(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Post("/api/v1/projects/123/notifications").
+		Reply(201).
+		BodyString(`{"id":99,"scheduled":10}`)
+
+	pushpad.Configure("TOKEN", 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := CreateWithContext(ctx, &NotificationCreateParams{ProjectID: pushpad.Int64(123), Body: pushpad.String("Hello")})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCreateNotificationWithIdempotencyKey(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Post("/api/v1/projects/123/notifications").
+		MatchHeader("Authorization", "Bearer TOKEN").
+		MatchHeader("Idempotency-Key", "campaign-42").
+		Reply(201).
+		BodyString(`{"id":99,"scheduled":10}`)
+
+	// a new client, so that no response is cached from a previous run
+	c := NewClient(pushpad.NewClient("TOKEN", 0))
+	params := &NotificationCreateParams{ProjectID: pushpad.Int64(123), Body: pushpad.String("Hello"), IdempotencyKey: pushpad.String("campaign-42")}
+	first, err := c.Create(params)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	second, err := c.Create(params)
+	if err != nil {
+		t.Fatalf("expected no error on the repeated create, got %s", err)
+	}
+	if first.ID != 99 || second.ID != 99 {
+		t.Errorf("expected notification ID 99 twice, got %d and %d", first.ID, second.ID)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected the notification to be created once")
+	}
+}
+
+func TestCreateNotificationValidationError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Post("/api/v1/projects/123/notifications").
+		Reply(422).
+		SetHeader("X-Request-Id", "abc123").
+		BodyString(`{"error":"validation error","errors":{"body":["can't be blank"]}}`)
+
+	pushpad.Configure("TOKEN", 0)
+	_, err := Create(&NotificationCreateParams{ProjectID: pushpad.Int64(123)})
+	if !errors.Is(err, pushpad.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+	var apiErr *pushpad.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if apiErr.Message != "validation error" {
+		t.Errorf("expected message validation error, got %q", apiErr.Message)
+	}
+	if len(apiErr.Errors["body"]) != 1 || apiErr.Errors["body"][0] != "can't be blank" {
+		t.Errorf("expected body error, got %v", apiErr.Errors)
+	}
+	if apiErr.RequestID != "abc123" {
+		t.Errorf("expected request ID abc123, got %q", apiErr.RequestID)
+	}
+}
+
+func TestAllNotifications(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`[{"id":3},{"id":2}]`)
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "2").
+		Reply(200).
+		BodyString(`[{"id":1}]`)
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "3").
+		Reply(200).
+		BodyString(`[]`)
+
+	pushpad.Configure("TOKEN", 123)
+	var ids []int64
+	for notification, err := range All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		ids = append(ids, notification.ID)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 2 || ids[2] != 1 {
+		t.Errorf("expected notifications [3 2 1], got %v", ids)
+	}
+}
+
+func TestAllNotificationsTimeWindow(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`[{"id":5,"created_at":"2025-03-10T10:00:00Z"},{"id":4,"created_at":"2025-03-06T10:00:00Z"}]`)
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "2").
+		Reply(200).
+		BodyString(`[{"id":3,"created_at":"2025-03-04T10:00:00Z"},{"id":2,"created_at":"2025-02-20T10:00:00Z"}]`)
+
+	pushpad.Configure("TOKEN", 0)
+	params := &NotificationAllParams{
+		ProjectID: pushpad.Int64(123),
+		Since:     pushpad.Time(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Until:     pushpad.Time(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)),
+	}
+	var ids []int64
+	for notification, err := range All(context.Background(), params) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		ids = append(ids, notification.ID)
+	}
+	if len(ids) != 2 || ids[0] != 4 || ids[1] != 3 {
+		t.Errorf("expected notifications [4 3], got %v", ids)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected both pages to be fetched")
+	}
+}
+
+func TestListPageNotifications(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "2").
+		Reply(200).
+		SetHeader("Link", `<https://pushpad.xyz/api/v1/projects/123/notifications?page=3>; rel="next", <https://pushpad.xyz/api/v1/projects/123/notifications?page=1>; rel="prev"`).
+		SetHeader("X-Total-Count", "120").
+		BodyString(`[{"id":1}]`)
+
+	pushpad.Configure("TOKEN", 123)
+	result, err := ListPage(&NotificationListParams{Page: pushpad.Int64(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(result.Notifications) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(result.Notifications))
+	}
+	if result.TotalCount != 120 || result.NextPage != 3 || result.PrevPage != 1 {
+		t.Errorf("unexpected metadata %+v", result.ListMeta)
+	}
+}
+
+func TestListPageNotificationsWithTotalCountOnly(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/notifications").
+		MatchParam("page", "2").
+		Reply(200).
+		SetHeader("X-Total-Count", "120").
+		BodyString(`[{"id":1}]`)
+
+	pushpad.Configure("TOKEN", 123)
+	result, err := ListPage(&NotificationListParams{Page: pushpad.Int64(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	// without a page size, the last and next pages are unknown
+	if result.TotalCount != 120 || result.Page != 2 || result.PrevPage != 1 || result.NextPage != 0 || result.LastPage != 0 {
+		t.Errorf("unexpected metadata %+v", result.ListMeta)
+	}
+}
+
+type operationRecorder struct {
+	info pushpad.RequestInfo
+}
+
+func (r *operationRecorder) RequestStart(ctx context.Context, info pushpad.RequestInfo) context.Context {
+	return ctx
+}
+
+func (r *operationRecorder) RequestEnd(ctx context.Context, info pushpad.RequestInfo, result pushpad.RequestResult) {
+	r.info = info
+}
+
+func TestCreateNotificationOperation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Post("/api/v1/projects/123/notifications").
+		Reply(201).
+		BodyString(`{"id":99,"scheduled":10}`)
+
+	recorder := &operationRecorder{}
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithInstrumentation(recorder)))
+	if _, err := c.Create(&NotificationCreateParams{Body: pushpad.String("Hello")}); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if recorder.info.Operation != "notification.Create" || recorder.info.ProjectID != 123 {
+		t.Errorf("unexpected request info %+v", recorder.info)
+	}
+}
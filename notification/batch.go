@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+// Defaults of BatchOptions.
+const (
+	DefaultBatchChunkSize   = 1000
+	DefaultBatchConcurrency = 4
+)
+
+// BatchOptions controls how SendBatch splits and sends the UIDs.
+type BatchOptions struct {
+	// ChunkSize is the maximum number of UIDs per request (defaults to DefaultBatchChunkSize).
+	ChunkSize int
+	// Concurrency is the maximum number of requests in flight (defaults to DefaultBatchConcurrency).
+	Concurrency int
+}
+
+// BatchResult aggregates the responses of the chunks sent by SendBatch.
+type BatchResult struct {
+	// Scheduled is the total number of subscriptions the notifications were sent to.
+	Scheduled int64
+	// NotificationIDs holds the ID of the notification created for each successful chunk, in chunk order.
+	NotificationIDs []int64
+	// UIDs holds the UIDs that had subscriptions, in chunk order.
+	UIDs []string
+	// Errors holds the failed chunks, in chunk order.
+	Errors []*ChunkError
+}
+
+// ChunkError is the error of a chunk that could not be sent.
+type ChunkError struct {
+	// Index is the position of the chunk, starting from 0.
+	Index int
+	// UIDs are the UIDs of the chunk, which can be sent again.
+	UIDs []string
+	Err  error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("pushpad: chunk %d (%d UIDs): %s", e.Index, len(e.UIDs), e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// Err joins the errors of the failed chunks, or returns nil if all the chunks were sent.
+func (r *BatchResult) Err() error {
+	errs := make([]error, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// SendBatch sends a notification to a large list of UIDs. See Client.SendBatch.
+func SendBatch(params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error) {
+	return defaultClient.SendBatch(params, opts)
+}
+
+func SendBatchWithContext(ctx context.Context, params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error) {
+	return defaultClient.SendBatchWithContext(ctx, params, opts)
+}
+
+func (c *Client) SendBatch(params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error) {
+	return c.SendBatchWithContext(context.Background(), params, opts)
+}
+
+// SendBatchWithContext splits params.UIDs into chunks and creates a
+// notification for each chunk, with at most opts.Concurrency requests in
+// flight. A failed chunk does not stop the others: the result aggregates the
+// successful chunks and lists the failed ones, and the returned error is
+// BatchResult.Err(). When params has an IdempotencyKey, each chunk is sent
+// with the key followed by the chunk index, so the batch is safe to repeat.
+func (c *Client) SendBatchWithContext(ctx context.Context, params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("pushpad: params are required")
+	}
+	if params.UIDs == nil {
+		return nil, fmt.Errorf("pushpad: UIDs are required")
+	}
+	chunkSize, concurrency := DefaultBatchChunkSize, DefaultBatchConcurrency
+	if opts != nil && opts.ChunkSize > 0 {
+		chunkSize = opts.ChunkSize
+	}
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	var chunks [][]string
+	for uids := *params.UIDs; len(uids) > 0; {
+		n := min(chunkSize, len(uids))
+		chunks = append(chunks, uids[:n:n])
+		uids = uids[n:]
+	}
+
+	responses := make([]*NotificationCreateResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				chunkParams := *params
+				chunkParams.UIDs = &chunks[i]
+				if params.IdempotencyKey != nil && *params.IdempotencyKey != "" {
+					chunkParams.IdempotencyKey = pushpad.String(*params.IdempotencyKey + "-" + strconv.Itoa(i))
+				}
+				responses[i], errs[i] = c.CreateWithContext(ctx, &chunkParams)
+			}
+		}()
+	}
+	for i := range chunks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := &BatchResult{NotificationIDs: []int64{}, UIDs: []string{}}
+	for i, response := range responses {
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, &ChunkError{Index: i, UIDs: chunks[i], Err: errs[i]})
+			continue
+		}
+		result.Scheduled += response.Scheduled
+		result.NotificationIDs = append(result.NotificationIDs, response.ID)
+		result.UIDs = append(result.UIDs, response.UIDs...)
+	}
+	return result, result.Err()
+}
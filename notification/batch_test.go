@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+func TestSendBatch(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]bool{}
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		var params NotificationCreateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		uids := *params.UIDs
+		mu.Lock()
+		keys[r.Header.Get("Idempotency-Key")] = true
+		mu.Unlock()
+		if uids[0] == "u4" {
+			w.WriteHeader(503)
+			return
+		}
+		id, _ := strconv.Atoi(uids[0][1:])
+		w.WriteHeader(201)
+		// only the first UID of each chunk is subscribed
+		json.NewEncoder(w).Encode(NotificationCreateResponse{ID: int64(100 + id), Scheduled: 2, UIDs: uids[:1]})
+	}))
+	defer server.Close()
+
+	var uids []string
+	for i := range 10 {
+		uids = append(uids, "u"+strconv.Itoa(i))
+	}
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	result, err := c.SendBatch(&NotificationCreateParams{
+		Body:           pushpad.String("Hello"),
+		UIDs:           pushpad.StringSlice(uids),
+		IdempotencyKey: pushpad.String("key"),
+	}, &BatchOptions{ChunkSize: 2, Concurrency: 2})
+
+	var chunkErr *ChunkError
+	if !errors.As(err, &chunkErr) || !errors.Is(err, pushpad.ErrServer) {
+		t.Fatalf("expected a chunk error, got %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Index != 2 || len(result.Errors[0].UIDs) != 2 || result.Errors[0].UIDs[0] != "u4" {
+		t.Errorf("unexpected chunk errors %+v", result.Errors)
+	}
+	if result.Scheduled != 8 {
+		t.Errorf("expected 8 scheduled, got %d", result.Scheduled)
+	}
+	if want := []int64{100, 102, 106, 108}; len(result.NotificationIDs) != 4 || result.NotificationIDs[0] != want[0] || result.NotificationIDs[3] != want[3] {
+		t.Errorf("unexpected notification IDs %v", result.NotificationIDs)
+	}
+	if len(result.UIDs) != 4 || result.UIDs[0] != "u0" || result.UIDs[3] != "u8" {
+		t.Errorf("unexpected UIDs %v", result.UIDs)
+	}
+	if len(keys) != 5 || !keys["key-0"] || !keys["key-4"] {
+		t.Errorf("unexpected idempotency keys %v", keys)
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight.Load())
+	}
+}
+
+func TestSendBatchRequiresUIDs(t *testing.T) {
+	c := NewClient(pushpad.NewClient("TOKEN", 123))
+	if _, err := c.SendBatch(&NotificationCreateParams{Body: pushpad.String("Hello")}, nil); err == nil {
+		t.Errorf("expected an error without UIDs")
+	}
+	result, err := c.SendBatch(&NotificationCreateParams{Body: pushpad.String("Hello"), UIDs: pushpad.StringSlice(nil)}, nil)
+	if err != nil || result.Scheduled != 0 || len(result.NotificationIDs) != 0 {
+		t.Errorf("expected an empty result, got %+v, %v", result, err)
+	}
+}
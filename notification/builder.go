@@ -0,0 +1,192 @@
+package notification
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/tags"
+)
+
+// Builder builds NotificationCreateParams with typed values:
+//
+//	params, err := notification.New("Hello").
+//	  Title("Hi").
+//	  TTL(48 * time.Hour).
+//	  Action(notification.Button{Title: "Open", TargetURL: target}).
+//	  ToUIDs("user1", "user2").
+//	  Build()
+type Builder struct {
+	params NotificationCreateParams
+	errs   pushpad.ValidationError
+}
+
+// Button is an action button of a notification.
+type Button struct {
+	Title     string
+	TargetURL *url.URL
+	Icon      *url.URL
+	// Action is an identifier of the action, passed to the service worker.
+	Action string
+}
+
+// New returns a builder for a notification with the given body.
+func New(body string) *Builder {
+	return &Builder{params: NotificationCreateParams{Body: pushpad.String(body)}}
+}
+
+// Project sends the notification to a project other than the default one.
+func (b *Builder) Project(projectID int64) *Builder {
+	b.params.ProjectID = pushpad.Int64(projectID)
+	return b
+}
+
+func (b *Builder) Title(title string) *Builder {
+	b.params.Title = pushpad.String(title)
+	return b
+}
+
+func (b *Builder) TargetURL(u *url.URL) *Builder {
+	b.params.TargetURL = urlString(u)
+	return b
+}
+
+func (b *Builder) IconURL(u *url.URL) *Builder {
+	b.params.IconURL = urlString(u)
+	return b
+}
+
+func (b *Builder) BadgeURL(u *url.URL) *Builder {
+	b.params.BadgeURL = urlString(u)
+	return b
+}
+
+func (b *Builder) ImageURL(u *url.URL) *Builder {
+	b.params.ImageURL = urlString(u)
+	return b
+}
+
+// TTL sets how long the notification is kept when the device is offline,
+// truncated to seconds.
+func (b *Builder) TTL(ttl time.Duration) *Builder {
+	b.params.TTL = pushpad.Int64(int64(ttl / time.Second))
+	return b
+}
+
+// RequireInteraction keeps the notification visible until the user interacts with it.
+func (b *Builder) RequireInteraction() *Builder {
+	b.params.RequireInteraction = pushpad.Bool(true)
+	return b
+}
+
+// Silent shows the notification without sound or vibration.
+func (b *Builder) Silent() *Builder {
+	b.params.Silent = pushpad.Bool(true)
+	return b
+}
+
+// Urgent delivers the notification immediately, even to devices that save battery.
+func (b *Builder) Urgent() *Builder {
+	b.params.Urgent = pushpad.Bool(true)
+	return b
+}
+
+// Starred marks the notification as important in the Pushpad dashboard.
+func (b *Builder) Starred() *Builder {
+	b.params.Starred = pushpad.Bool(true)
+	return b
+}
+
+func (b *Builder) CustomData(data string) *Builder {
+	b.params.CustomData = pushpad.String(data)
+	return b
+}
+
+// CustomMetrics adds custom metrics to track with the notification.
+func (b *Builder) CustomMetrics(metrics ...string) *Builder {
+	b.params.CustomMetrics = appendTo(b.params.CustomMetrics, metrics...)
+	return b
+}
+
+// Action adds an action button.
+func (b *Builder) Action(button Button) *Builder {
+	action := NotificationActionParams{
+		Title:     pushpad.String(button.Title),
+		TargetURL: urlString(button.TargetURL),
+		Icon:      urlString(button.Icon),
+	}
+	if button.Action != "" {
+		action.Action = pushpad.String(button.Action)
+	}
+	b.params.Actions = appendTo(b.params.Actions, action)
+	return b
+}
+
+// SendAt schedules the notification.
+func (b *Builder) SendAt(t time.Time) *Builder {
+	b.params.SendAt = pushpad.Time(t)
+	return b
+}
+
+// ToUIDs sends the notification only to the subscriptions of the given users.
+func (b *Builder) ToUIDs(uids ...string) *Builder {
+	b.params.UIDs = appendTo(b.params.UIDs, uids...)
+	return b
+}
+
+// ToTags sends the notification only to the subscriptions that match one of
+// the tag expressions.
+func (b *Builder) ToTags(exprs ...tags.Expr) *Builder {
+	rendered, err := tags.Build(exprs...)
+	if err != nil {
+		b.errs.Add("tags", err.Error())
+		return b
+	}
+	b.params.Tags = appendTo(b.params.Tags, *rendered...)
+	return b
+}
+
+// IdempotencyKey makes the create safe to repeat, see NotificationCreateParams.IdempotencyKey.
+func (b *Builder) IdempotencyKey(key string) *Builder {
+	b.params.IdempotencyKey = pushpad.String(key)
+	return b
+}
+
+// Build returns the params, or a *pushpad.ValidationError with the problems
+// found by the builder and by NotificationCreateParams.Validate.
+func (b *Builder) Build() (*NotificationCreateParams, error) {
+	errs := pushpad.ValidationError{}
+	var validationErr *pushpad.ValidationError
+	if err := b.params.Validate(); errors.As(err, &validationErr) {
+		errs = *validationErr
+	}
+	for field, messages := range b.errs.Errors {
+		for _, message := range messages {
+			errs.Add(field, message)
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	params := b.params
+	return &params, nil
+}
+
+func urlString(u *url.URL) *string {
+	if u == nil {
+		return nil
+	}
+	return pushpad.String(u.String())
+}
+
+// appendTo appends values to the slice pointed to by s, which may be nil.
+func appendTo[T any](s *[]T, values ...T) *[]T {
+	if s == nil {
+		values = slices.Clone(values)
+		return &values
+	}
+	result := append(*s, values...)
+	return &result
+}
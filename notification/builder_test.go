@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/tags"
+)
+
+func TestBuilder(t *testing.T) {
+	target, _ := url.Parse("https://example.com/page")
+	icon, _ := url.Parse("https://example.com/icon.png")
+	sendAt := time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	params, err := New("Hello").
+		Project(5).
+		Title("Hi").
+		TargetURL(target).
+		IconURL(icon).
+		TTL(48*time.Hour).
+		RequireInteraction().
+		Urgent().
+		CustomMetrics("examples").
+		Action(Button{Title: "Open", TargetURL: target, Icon: icon, Action: "open"}).
+		SendAt(sendAt).
+		ToUIDs("user1", "user2").
+		ToTags(tags.And(tags.Tag("sports"), tags.Not(tags.Tag("optout")))).
+		IdempotencyKey("key").
+		Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if *params.ProjectID != 5 || *params.IdempotencyKey != "key" {
+		t.Errorf("unexpected params %+v", params)
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	want := `{"title":"Hi","body":"Hello","target_url":"https://example.com/page","icon_url":"https://example.com/icon.png","ttl":172800,"require_interaction":true,"urgent":true,"actions":[{"title":"Open","target_url":"https://example.com/page","icon":"https://example.com/icon.png","action":"open"}],"send_at":"2100-01-02T03:04:05Z","custom_metrics":["examples"],"uids":["user1","user2"],"tags":["sports \u0026\u0026 !optout"]}`
+	if string(payload) != want {
+		t.Errorf("unexpected payload:\n%s\nwant\n%s", payload, want)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	relative, _ := url.Parse("/page")
+	_, err := New("").
+		TargetURL(relative).
+		TTL(-time.Second).
+		ToTags(tags.Tag("invalid tag")).
+		Build()
+
+	var validationErr *pushpad.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	for _, field := range []string{"body", "target_url", "ttl", "tags"} {
+		if len(validationErr.Errors[field]) != 1 {
+			t.Errorf("expected an error for %s, got %v", field, validationErr.Errors)
+		}
+	}
+}
+
+func TestBuilderAppends(t *testing.T) {
+	uids := []string{"a", "b"}
+	params, err := New("Hello").ToUIDs(uids...).ToUIDs("c").Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	uids[0] = "changed"
+	if got := *params.UIDs; len(got) != 3 || got[0] != "a" || got[2] != "c" {
+		t.Errorf("unexpected UIDs %v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/tags"
+)
+
+// LanguageTagPrefix is the prefix of the tags that hold the language of a
+// subscription, such as "lang:en".
+const LanguageTagPrefix = "lang:"
+
+// Localization is the content of a notification in one language. Empty
+// fields are not sent, so the project defaults apply.
+type Localization struct {
+	Title     string
+	Body      string
+	TargetURL string
+}
+
+// LocalizedParams describes a notification sent in several languages.
+type LocalizedParams struct {
+	// NotificationCreateParams holds the fields shared by all the languages.
+	// Its Tags, if any, restrict the audience of every language.
+	NotificationCreateParams
+	// Localizations holds the content by locale, such as "en", "it" or
+	// "pt-BR". The language tag of a locale is LanguageTagPrefix followed by
+	// the locale with hyphens replaced by underscores, such as "lang:pt_BR",
+	// since tags allow only letters, digits, underscores and colons.
+	Localizations map[string]Localization
+	// FallbackLocale is the locale sent to the subscriptions without a
+	// language tag of Localizations. It is required.
+	FallbackLocale string
+}
+
+// LocalizedResult holds the results of SendLocalized by locale.
+type LocalizedResult struct {
+	// Responses holds the response of each locale that was sent.
+	Responses map[string]*NotificationCreateResponse
+	// Scheduled is the total number of subscriptions the notifications were sent to.
+	Scheduled int64
+	// Errors holds the error of each locale that failed.
+	Errors map[string]error
+}
+
+// Err joins the errors of the failed locales, or returns nil if all the locales were sent.
+func (r *LocalizedResult) Err() error {
+	var errs []error
+	for _, locale := range slices.Sorted(maps.Keys(r.Errors)) {
+		errs = append(errs, fmt.Errorf("pushpad: locale %s: %w", locale, r.Errors[locale]))
+	}
+	return errors.Join(errs...)
+}
+
+// SendLocalized sends a notification in several languages. See Client.SendLocalized.
+func SendLocalized(params *LocalizedParams) (*LocalizedResult, error) {
+	return defaultClient.SendLocalized(params)
+}
+
+func SendLocalizedWithContext(ctx context.Context, params *LocalizedParams) (*LocalizedResult, error) {
+	return defaultClient.SendLocalizedWithContext(ctx, params)
+}
+
+func (c *Client) SendLocalized(params *LocalizedParams) (*LocalizedResult, error) {
+	return c.SendLocalizedWithContext(context.Background(), params)
+}
+
+// SendLocalizedWithContext creates one notification per locale, sent to the
+// subscriptions tagged with the LanguageTag of the locale. The notification
+// of the fallback locale is also sent to the subscriptions without the tag of
+// any other locale, e.g. "lang:en || !(lang:en || lang:it)". A failed locale
+// does not stop the others: the returned error is LocalizedResult.Err(). When
+// params has an IdempotencyKey, each locale is sent with the key followed by
+// the locale. An invalid locale fails before any notification is sent.
+func (c *Client) SendLocalizedWithContext(ctx context.Context, params *LocalizedParams) (*LocalizedResult, error) {
+	if params == nil {
+		return nil, fmt.Errorf("pushpad: params are required")
+	}
+	if _, ok := params.Localizations[params.FallbackLocale]; !ok {
+		return nil, fmt.Errorf("pushpad: fallback locale %q has no localization", params.FallbackLocale)
+	}
+	var audience tags.Expr
+	if params.Tags != nil {
+		var err error
+		if audience, err = tags.ParseList(*params.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	// build the params of every locale first, so that an invalid locale
+	// fails before any notification is sent
+	locales := slices.Sorted(maps.Keys(params.Localizations))
+	languageTags := make([]tags.Expr, len(locales))
+	for i, locale := range locales {
+		if !tags.ValidTag(LanguageTag(locale)) {
+			return nil, fmt.Errorf("pushpad: invalid locale %q", locale)
+		}
+		languageTags[i] = tags.Tag(LanguageTag(locale))
+	}
+	localeParams := make([]NotificationCreateParams, len(locales))
+	for i, locale := range locales {
+		expr := languageTags[i]
+		if locale == params.FallbackLocale {
+			expr = tags.Or(expr, tags.Not(tags.Or(languageTags[0], languageTags[1:]...)))
+		}
+		if audience != nil {
+			expr = tags.And(audience, expr)
+		}
+		expressions, err := tags.Build(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		localization := params.Localizations[locale]
+		p := params.NotificationCreateParams
+		p.Tags = expressions
+		p.Title = optionalString(localization.Title, params.Title)
+		p.Body = optionalString(localization.Body, params.Body)
+		p.TargetURL = optionalString(localization.TargetURL, params.TargetURL)
+		if params.IdempotencyKey != nil && *params.IdempotencyKey != "" {
+			p.IdempotencyKey = pushpad.String(*params.IdempotencyKey + "-" + locale)
+		}
+		localeParams[i] = p
+	}
+
+	result := &LocalizedResult{Responses: map[string]*NotificationCreateResponse{}, Errors: map[string]error{}}
+	for i, locale := range locales {
+		response, err := c.CreateWithContext(ctx, &localeParams[i])
+		if err != nil {
+			result.Errors[locale] = err
+			continue
+		}
+		result.Responses[locale] = response
+		result.Scheduled += response.Scheduled
+	}
+	return result, result.Err()
+}
+
+// LanguageTag returns the language tag of a locale, such as "lang:pt_BR" for "pt-BR".
+func LanguageTag(locale string) string {
+	return LanguageTagPrefix + strings.ReplaceAll(locale, "-", "_")
+}
+
+// optionalString returns value, or fallback when value is empty.
+func optionalString(value string, fallback *string) *string {
+	if value == "" {
+		return fallback
+	}
+	return pushpad.String(value)
+}
@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+func TestSendLocalized(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]NotificationCreateParams{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params NotificationCreateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		mu.Lock()
+		sent[(*params.Tags)[0]] = params
+		mu.Unlock()
+		if *params.Body == "Ciao" {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":10}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	result, err := c.SendLocalized(&LocalizedParams{
+		NotificationCreateParams: NotificationCreateParams{
+			Title: pushpad.String("News"),
+			Tags:  pushpad.StringSlice([]string{"news", "sports"}),
+			TTL:   pushpad.Int64(60),
+		},
+		Localizations: map[string]Localization{
+			"en": {Body: "Hello", TargetURL: "https://example.com/en"},
+			"it": {Title: "Notizie", Body: "Ciao"},
+			"fr": {Body: "Bonjour"},
+		},
+		FallbackLocale: "en",
+	})
+
+	if !errors.Is(err, pushpad.ErrServer) || len(result.Errors) != 1 || result.Errors["it"] == nil {
+		t.Fatalf("expected the it locale to fail, got %v", err)
+	}
+	if len(result.Responses) != 2 || result.Scheduled != 20 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	en, ok := sent["(news || sports) && (lang:en || !(lang:en || lang:fr || lang:it))"]
+	if !ok {
+		t.Fatalf("expected the fallback locale with the catch-all, got %v", sent)
+	}
+	if *en.Title != "News" || *en.Body != "Hello" || *en.TargetURL != "https://example.com/en" || *en.TTL != 60 {
+		t.Errorf("unexpected params %+v", en)
+	}
+	it, ok := sent["(news || sports) && lang:it"]
+	if !ok || *it.Title != "Notizie" || it.TargetURL != nil {
+		t.Errorf("unexpected params %+v", it)
+	}
+	if _, ok := sent["(news || sports) && lang:fr"]; !ok {
+		t.Errorf("expected the fr locale, got %v", sent)
+	}
+}
+
+func TestSendLocalizedWithoutAudience(t *testing.T) {
+	var expressions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params NotificationCreateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		expressions = append(expressions, (*params.Tags)...)
+		if r.Header.Get("Idempotency-Key") != "key-"+(*params.Body) {
+			t.Errorf("unexpected idempotency key %q", r.Header.Get("Idempotency-Key"))
+		}
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	_, err := c.SendLocalized(&LocalizedParams{
+		NotificationCreateParams: NotificationCreateParams{IdempotencyKey: pushpad.String("key")},
+		Localizations:            map[string]Localization{"en": {Body: "en"}, "it": {Body: "it"}},
+		FallbackLocale:           "it",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(expressions) != 2 || expressions[0] != "lang:en" || expressions[1] != "lang:it || !(lang:en || lang:it)" {
+		t.Errorf("unexpected expressions %q", expressions)
+	}
+}
+
+func TestSendLocalizedHyphenatedLocale(t *testing.T) {
+	var expressions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params NotificationCreateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		expressions = append(expressions, (*params.Tags)...)
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	result, err := c.SendLocalized(&LocalizedParams{
+		Localizations:  map[string]Localization{"en": {Body: "Hello"}, "pt-BR": {Body: "Olá"}},
+		FallbackLocale: "en",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if result.Responses["pt-BR"] == nil {
+		t.Errorf("expected a response for pt-BR, got %+v", result.Responses)
+	}
+	if len(expressions) != 2 || expressions[0] != "lang:en || !(lang:en || lang:pt_BR)" || expressions[1] != "lang:pt_BR" {
+		t.Errorf("unexpected expressions %q", expressions)
+	}
+}
+
+func TestSendLocalizedInvalid(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	if _, err := c.SendLocalized(&LocalizedParams{Localizations: map[string]Localization{"en": {Body: "Hello"}}, FallbackLocale: "it"}); err == nil {
+		t.Errorf("expected an error for a missing fallback locale")
+	}
+	if _, err := c.SendLocalized(&LocalizedParams{Localizations: map[string]Localization{"en us": {Body: "Hello"}}, FallbackLocale: "en us"}); err == nil {
+		t.Errorf("expected an error for an invalid locale")
+	}
+	// "de" and "en" sort before the invalid locale, but are not sent either
+	result, err := c.SendLocalized(&LocalizedParams{
+		Localizations:  map[string]Localization{"de": {Body: "Hallo"}, "en": {Body: "Hello"}, "fr.ca": {Body: "Bonjour"}},
+		FallbackLocale: "en",
+	})
+	if err == nil || !strings.Contains(err.Error(), `"fr.ca"`) || result != nil {
+		t.Errorf("expected an error for the fr.ca locale, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests, got %d", requests)
+	}
+}
@@ -1,6 +1,10 @@
 package notification
 
-import "time"
+import (
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
 
 // NotificationAction represents a notification action button in responses.
 type NotificationAction struct {
@@ -58,6 +62,9 @@ type NotificationActionParams struct {
 // NotificationCreateParams represents a notification create payload.
 type NotificationCreateParams struct {
 	ProjectID          *int64                `json:"-"`
+	// IdempotencyKey is sent as the Idempotency-Key header: creating a notification
+	// again with the same key returns the original response instead of sending twice.
+	IdempotencyKey     *string               `json:"-"`
 	Title              *string               `json:"title,omitempty"`
 	Body               *string               `json:"body,omitempty"`
 	TargetURL          *string               `json:"target_url,omitempty"`
@@ -83,6 +90,21 @@ type NotificationListParams struct {
 	Page      *int64
 }
 
+// NotificationListResult is a page of notifications with its pagination metadata.
+type NotificationListResult struct {
+	Notifications []Notification
+	pushpad.ListMeta
+}
+
+// NotificationAllParams controls notification iteration.
+type NotificationAllParams struct {
+	ProjectID *int64
+	// Since stops the iteration at the first notification created before this time.
+	Since *time.Time
+	// Until skips the notifications created after this time.
+	Until *time.Time
+}
+
 // NotificationGetParams controls notification fetches.
 type NotificationGetParams struct{}
 
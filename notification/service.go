@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"context"
+	"iter"
+)
+
+// NotificationService is the set of notification API calls, implemented by *Client.
+// Depend on it instead of *Client to substitute a fake in tests.
+type NotificationService interface {
+	List(params *NotificationListParams) ([]Notification, error)
+	ListWithContext(ctx context.Context, params *NotificationListParams) ([]Notification, error)
+	ListPage(params *NotificationListParams) (*NotificationListResult, error)
+	ListPageWithContext(ctx context.Context, params *NotificationListParams) (*NotificationListResult, error)
+	All(ctx context.Context, params *NotificationAllParams) iter.Seq2[Notification, error]
+	Create(params *NotificationCreateParams) (*NotificationCreateResponse, error)
+	CreateWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error)
+	Send(params *NotificationCreateParams) (*NotificationCreateResponse, error)
+	SendWithContext(ctx context.Context, params *NotificationCreateParams) (*NotificationCreateResponse, error)
+	SendBatch(params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error)
+	SendBatchWithContext(ctx context.Context, params *NotificationCreateParams, opts *BatchOptions) (*BatchResult, error)
+	SendLocalized(params *LocalizedParams) (*LocalizedResult, error)
+	SendLocalizedWithContext(ctx context.Context, params *LocalizedParams) (*LocalizedResult, error)
+	Get(notificationID int64, params *NotificationGetParams) (*Notification, error)
+	GetWithContext(ctx context.Context, notificationID int64, params *NotificationGetParams) (*Notification, error)
+	Cancel(notificationID int64, params *NotificationCancelParams) error
+	CancelWithContext(ctx context.Context, notificationID int64, params *NotificationCancelParams) error
+	WaitForDelivery(ctx context.Context, notificationID int64, opts *WatchOptions) (*Notification, error)
+	Watch(ctx context.Context, notificationID int64, opts *WatchOptions) <-chan WatchEvent
+}
+
+var _ NotificationService = (*Client)(nil)
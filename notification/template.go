@@ -0,0 +1,211 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+// Templates is a set of named notification templates. The Title, Body,
+// TargetURL and action titles of a template are text/template templates,
+// rendered with the data passed to Render; a missing variable is an error.
+// Templates is safe for concurrent use.
+//
+//	templates := notification.NewTemplates()
+//	templates.Add("welcome", notification.NotificationCreateParams{
+//	  Title: pushpad.String("Welcome {{.Name}}"),
+//	  Body:  pushpad.String("Thanks for subscribing, {{.Name}}!"),
+//	})
+//	params, err := templates.Render("welcome", map[string]any{"Name": "Alice"})
+type Templates struct {
+	mu        sync.RWMutex
+	funcs     template.FuncMap
+	templates map[string]*Template
+}
+
+// Template is a parsed notification template.
+type Template struct {
+	name      string
+	projectID *int64
+	// base is the JSON encoding of the params, decoded again by every render
+	// so that the rendered params never share memory.
+	base         []byte
+	title        *template.Template
+	body         *template.Template
+	targetURL    *template.Template
+	actionTitles []*template.Template
+}
+
+// NewTemplates returns an empty set of templates.
+func NewTemplates() *Templates {
+	return &Templates{templates: map[string]*Template{}}
+}
+
+// Funcs adds functions to the templates added after the call.
+func (t *Templates) Funcs(funcs template.FuncMap) *Templates {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.funcs == nil {
+		t.funcs = template.FuncMap{}
+	}
+	for name, fn := range funcs {
+		t.funcs[name] = fn
+	}
+	return t
+}
+
+// Add parses params as a template and registers it with name, replacing any
+// template with the same name.
+func (t *Templates) Add(name string, params NotificationCreateParams) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tmpl := &Template{name: name, projectID: params.ProjectID}
+	var err error
+	if tmpl.title, err = t.parse(name, "title", params.Title); err != nil {
+		return err
+	}
+	if tmpl.body, err = t.parse(name, "body", params.Body); err != nil {
+		return err
+	}
+	if tmpl.targetURL, err = t.parse(name, "target_url", params.TargetURL); err != nil {
+		return err
+	}
+	if params.Actions != nil {
+		for i, action := range *params.Actions {
+			title, err := t.parse(name, fmt.Sprintf("actions[%d].title", i), action.Title)
+			if err != nil {
+				return err
+			}
+			tmpl.actionTitles = append(tmpl.actionTitles, title)
+		}
+	}
+	if tmpl.base, err = json.Marshal(params); err != nil {
+		return err
+	}
+	t.templates[name] = tmpl
+	return nil
+}
+
+func (t *Templates) parse(name, field string, text *string) (*template.Template, error) {
+	if text == nil {
+		return nil, nil
+	}
+	tmpl, err := template.New(name + "." + field).Option("missingkey=error").Funcs(t.funcs).Parse(*text)
+	if err != nil {
+		return nil, fmt.Errorf("pushpad: template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// ParseFS adds the templates in the files of fsys that match pattern, such
+// as "templates/*.json". Each file holds the JSON of NotificationCreateParams
+// and the template is named after the file, without the extension.
+func (t *Templates) ParseFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("pushpad: no template files match %q", pattern)
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		var params NotificationCreateParams
+		if err := json.Unmarshal(data, &params); err != nil {
+			return fmt.Errorf("pushpad: template file %s: %w", file, err)
+		}
+		base := path.Base(file)
+		if err := t.Add(strings.TrimSuffix(base, path.Ext(base)), params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseDir adds the templates in the .json files of dir. See ParseFS.
+func (t *Templates) ParseDir(dir string) error {
+	return t.ParseFS(os.DirFS(dir), "*.json")
+}
+
+// Lookup returns the template with the given name, or nil.
+func (t *Templates) Lookup(name string) *Template {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.templates[name]
+}
+
+// Names returns the names of the templates, in no particular order.
+func (t *Templates) Names() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	names := make([]string, 0, len(t.templates))
+	for name := range t.templates {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Render renders the template with the given name.
+func (t *Templates) Render(name string, data any) (*NotificationCreateParams, error) {
+	tmpl := t.Lookup(name)
+	if tmpl == nil {
+		return nil, fmt.Errorf("pushpad: template %s not found", name)
+	}
+	return tmpl.Render(data)
+}
+
+// Name returns the name of the template.
+func (t *Template) Name() string {
+	return t.name
+}
+
+// Render returns new params with the templates executed with data. It fails
+// when the data lacks a variable used by the templates.
+func (t *Template) Render(data any) (*NotificationCreateParams, error) {
+	var params NotificationCreateParams
+	if err := json.Unmarshal(t.base, &params); err != nil {
+		return nil, err
+	}
+	if t.projectID != nil {
+		params.ProjectID = pushpad.Int64(*t.projectID)
+	}
+
+	var err error
+	if params.Title, err = execute(t.title, data); err != nil {
+		return nil, err
+	}
+	if params.Body, err = execute(t.body, data); err != nil {
+		return nil, err
+	}
+	if params.TargetURL, err = execute(t.targetURL, data); err != nil {
+		return nil, err
+	}
+	for i, title := range t.actionTitles {
+		if (*params.Actions)[i].Title, err = execute(title, data); err != nil {
+			return nil, err
+		}
+	}
+	return &params, nil
+}
+
+func execute(tmpl *template.Template, data any) (*string, error) {
+	if tmpl == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("pushpad: %w", err)
+	}
+	return pushpad.String(b.String()), nil
+}
@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+func TestTemplatesRender(t *testing.T) {
+	templates := NewTemplates()
+	err := templates.Add("order", NotificationCreateParams{
+		ProjectID: pushpad.Int64(5),
+		Title:     pushpad.String("Order {{.ID}}"),
+		Body:      pushpad.String("Hi {{.Name}}, your order has shipped"),
+		TargetURL: pushpad.String("https://example.com/orders/{{.ID}}"),
+		TTL:       pushpad.Int64(3600),
+		Actions: &[]NotificationActionParams{
+			{Title: pushpad.String("Track order {{.ID}}"), TargetURL: pushpad.String("https://example.com/track")},
+		},
+		Tags: pushpad.StringSlice([]string{"orders"}),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	params, err := templates.Render("order", map[string]any{"ID": 42, "Name": "Alice"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if *params.Title != "Order 42" || *params.Body != "Hi Alice, your order has shipped" || *params.TargetURL != "https://example.com/orders/42" {
+		t.Errorf("unexpected params %+v", params)
+	}
+	if *params.ProjectID != 5 || *params.TTL != 3600 || (*params.Tags)[0] != "orders" {
+		t.Errorf("expected the other fields to be copied, got %+v", params)
+	}
+	if actions := *params.Actions; *actions[0].Title != "Track order 42" || *actions[0].TargetURL != "https://example.com/track" {
+		t.Errorf("unexpected actions %+v", actions)
+	}
+
+	// renders do not share memory
+	(*params.Tags)[0] = "changed"
+	other, err := templates.Render("order", struct {
+		ID   int
+		Name string
+	}{7, "Bob"})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if (*other.Tags)[0] != "orders" || *other.Title != "Order 7" {
+		t.Errorf("unexpected params %+v", other)
+	}
+}
+
+func TestTemplatesMissingVariable(t *testing.T) {
+	templates := NewTemplates()
+	if err := templates.Add("greeting", NotificationCreateParams{Body: pushpad.String("Hi {{.Name}}")}); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, err := templates.Render("greeting", map[string]any{}); err == nil || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("expected a missing key error, got %v", err)
+	}
+	if _, err := templates.Render("greeting", struct{ Other string }{}); err == nil {
+		t.Errorf("expected a missing field error")
+	}
+	if _, err := templates.Render("unknown", nil); err == nil {
+		t.Errorf("expected an error for an unknown template")
+	}
+}
+
+func TestTemplatesInvalid(t *testing.T) {
+	templates := NewTemplates()
+	if err := templates.Add("broken", NotificationCreateParams{Body: pushpad.String("Hi {{.Name")}); err == nil {
+		t.Errorf("expected a parse error")
+	}
+	if templates.Lookup("broken") != nil {
+		t.Errorf("expected the broken template not to be registered")
+	}
+}
+
+func TestTemplatesFuncs(t *testing.T) {
+	templates := NewTemplates().Funcs(template.FuncMap{"upper": strings.ToUpper})
+	if err := templates.Add("shout", NotificationCreateParams{Body: pushpad.String("{{upper .}}")}); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	params, err := templates.Render("shout", "hello")
+	if err != nil || *params.Body != "HELLO" {
+		t.Errorf("unexpected result %v, %v", params, err)
+	}
+}
+
+func TestTemplatesParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/welcome.json": {Data: []byte(`{"title":"Welcome {{.Name}}","body":"Thanks for subscribing","ttl":60}`)},
+		"templates/readme.txt":   {Data: []byte(`not a template`)},
+	}
+	templates := NewTemplates()
+	if err := templates.ParseFS(fsys, "templates/*.json"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if names := templates.Names(); len(names) != 1 || names[0] != "welcome" {
+		t.Errorf("unexpected templates %v", names)
+	}
+	params, err := templates.Render("welcome", map[string]string{"Name": "Alice"})
+	if err != nil || *params.Title != "Welcome Alice" || *params.TTL != 60 {
+		t.Errorf("unexpected result %+v, %v", params, err)
+	}
+
+	if err := templates.ParseFS(fsys, "missing/*.json"); err == nil {
+		t.Errorf("expected an error when no file matches")
+	}
+}
+
+func TestTemplatesParseDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "alert.json"), []byte(`{"body":"Alert: {{.}}"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	templates := NewTemplates()
+	if err := templates.ParseDir(dir); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	params, err := templates.Render("alert", "disk full")
+	if err != nil || *params.Body != "Alert: disk full" {
+		t.Errorf("unexpected result %+v, %v", params, err)
+	}
+}
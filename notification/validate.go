@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+// Limits checked by NotificationCreateParams.Validate.
+const (
+	MaxActions       = 2
+	MaxCustomMetrics = 3
+)
+
+// Validate checks the params on the client side and returns a
+// *pushpad.ValidationError with all the problems found, or nil. It catches a
+// missing body, too many actions or custom metrics, URLs that are not
+// absolute, a negative TTL and a SendAt in the past.
+func (p *NotificationCreateParams) Validate() error {
+	errs := &pushpad.ValidationError{}
+	if p.Body == nil || *p.Body == "" {
+		errs.Add("body", "can't be blank")
+	}
+	validateURL(errs, "target_url", p.TargetURL)
+	validateURL(errs, "icon_url", p.IconURL)
+	validateURL(errs, "badge_url", p.BadgeURL)
+	validateURL(errs, "image_url", p.ImageURL)
+	if p.TTL != nil && *p.TTL < 0 {
+		errs.Add("ttl", "must be greater than or equal to 0")
+	}
+	if p.Actions != nil {
+		if len(*p.Actions) > MaxActions {
+			errs.Add("actions", fmt.Sprintf("must have at most %d items", MaxActions))
+		}
+		for i, action := range *p.Actions {
+			validateURL(errs, fmt.Sprintf("actions[%d].target_url", i), action.TargetURL)
+			validateURL(errs, fmt.Sprintf("actions[%d].icon", i), action.Icon)
+		}
+	}
+	if p.CustomMetrics != nil && len(*p.CustomMetrics) > MaxCustomMetrics {
+		errs.Add("custom_metrics", fmt.Sprintf("must have at most %d items", MaxCustomMetrics))
+	}
+	if p.SendAt != nil && p.SendAt.Before(time.Now()) {
+		errs.Add("send_at", "must be in the future")
+	}
+	return errs.Err()
+}
+
+func validateURL(errs *pushpad.ValidationError, field string, value *string) {
+	if value == nil || *value == "" {
+		return
+	}
+	u, err := url.Parse(*value)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		errs.Add(field, "must be an absolute URL")
+	}
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+func TestValidate(t *testing.T) {
+	params := &NotificationCreateParams{
+		Body:      pushpad.String("Hello"),
+		TargetURL: pushpad.String("https://example.com/page"),
+		IconURL:   pushpad.String("https://example.com/icon.png"),
+		TTL:       pushpad.Int64(0),
+		Actions: &[]NotificationActionParams{
+			{Title: pushpad.String("Open"), TargetURL: pushpad.String("https://example.com")},
+		},
+		CustomMetrics: pushpad.StringSlice([]string{"a", "b", "c"}),
+		SendAt:        pushpad.Time(time.Now().Add(time.Hour)),
+	}
+	if err := params.Validate(); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	params := &NotificationCreateParams{
+		TargetURL: pushpad.String("/relative"),
+		IconURL:   pushpad.String("icon.png"),
+		ImageURL:  pushpad.String("https://example.com/image.png"),
+		TTL:       pushpad.Int64(-1),
+		Actions: &[]NotificationActionParams{
+			{Title: pushpad.String("A")},
+			{Title: pushpad.String("B"), TargetURL: pushpad.String("example.com")},
+			{Title: pushpad.String("C")},
+		},
+		CustomMetrics: pushpad.StringSlice([]string{"a", "b", "c", "d"}),
+		SendAt:        pushpad.Time(time.Now().Add(-time.Minute)),
+	}
+	err := params.Validate()
+	if !errors.Is(err, pushpad.ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	var validationErr *pushpad.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	want := []string{
+		"actions must have at most 2 items",
+		"actions[1].target_url must be an absolute URL",
+		"body can't be blank",
+		"custom_metrics must have at most 3 items",
+		"icon_url must be an absolute URL",
+		"send_at must be in the future",
+		"target_url must be an absolute URL",
+		"ttl must be greater than or equal to 0",
+	}
+	if got := validationErr.FieldErrors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected errors:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCreateWithParamsValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(201)
+		w.Write([]byte(`{"id":1,"scheduled":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL), pushpad.WithParamsValidation()))
+	if _, err := c.Create(&NotificationCreateParams{}); !errors.Is(err, pushpad.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+
+	if _, err := c.Create(&NotificationCreateParams{Body: pushpad.String("Hello")}); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
@@ -0,0 +1,134 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+// Defaults of WatchOptions.
+const (
+	DefaultWatchInitialInterval = time.Second
+	DefaultWatchMaxInterval     = 30 * time.Second
+	DefaultWatchStablePolls     = 3
+)
+
+// WatchOptions controls how WaitForDelivery and Watch poll a notification.
+type WatchOptions struct {
+	// InitialInterval is the delay before the second poll (defaults to
+	// DefaultWatchInitialInterval). The delay doubles after every poll.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls (defaults to DefaultWatchMaxInterval).
+	MaxInterval time.Duration
+	// StablePolls is the number of consecutive polls with the same counts
+	// after which the delivery is considered complete (defaults to DefaultWatchStablePolls).
+	StablePolls int
+	// OnProgress, if set, is called with the notification after every poll.
+	OnProgress func(*Notification)
+}
+
+// WatchEvent is a progress update sent by Watch.
+type WatchEvent struct {
+	Notification *Notification
+	// Err is set on the last event when polling failed or the context is done.
+	Err error
+}
+
+// WaitForDelivery waits for the delivery of a notification. See Client.WaitForDelivery.
+func WaitForDelivery(ctx context.Context, notificationID int64, opts *WatchOptions) (*Notification, error) {
+	return defaultClient.WaitForDelivery(ctx, notificationID, opts)
+}
+
+// Watch streams the delivery progress of a notification. See Client.Watch.
+func Watch(ctx context.Context, notificationID int64, opts *WatchOptions) <-chan WatchEvent {
+	return defaultClient.Watch(ctx, notificationID, opts)
+}
+
+// WaitForDelivery polls a notification, with an exponential backoff, until
+// its SuccessfullySent and OpenedCount have not changed for opts.StablePolls
+// consecutive polls or it is cancelled, and returns it. A scheduled
+// notification is polled until after its SendAt. When ctx is done or a poll
+// fails, it returns the last notification fetched, if any, with the error.
+func (c *Client) WaitForDelivery(ctx context.Context, notificationID int64, opts *WatchOptions) (*Notification, error) {
+	o := WatchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = DefaultWatchInitialInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = DefaultWatchMaxInterval
+	}
+	if o.StablePolls <= 0 {
+		o.StablePolls = DefaultWatchStablePolls
+	}
+
+	var last *Notification
+	stable := 0
+	interval := o.InitialInterval
+	for {
+		n, err := c.GetWithContext(ctx, notificationID, nil)
+		if err != nil {
+			return last, err
+		}
+		if o.OnProgress != nil {
+			o.OnProgress(n)
+		}
+		if n.Cancelled {
+			return n, nil
+		}
+		if last != nil && n.SuccessfullySent == last.SuccessfullySent && n.OpenedCount == last.OpenedCount && !n.SendAt.After(time.Now()) {
+			stable++
+		} else {
+			stable = 0
+		}
+		last = n
+		if stable >= o.StablePolls {
+			return n, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+		interval = min(interval*2, o.MaxInterval)
+	}
+}
+
+// Watch is like WaitForDelivery but streams the notification after every
+// poll on the returned channel, which is closed when the watch ends. The last
+// event carries the error, if any. Cancel ctx to stop early: the channel is
+// then closed even if the caller stops receiving, and the last event is sent
+// only if the caller is still receiving. opts.OnProgress is also called if set.
+func (c *Client) Watch(ctx context.Context, notificationID int64, opts *WatchOptions) <-chan WatchEvent {
+	// the buffer lets the last event be delivered after ctx is done
+	events := make(chan WatchEvent, 1)
+	o := WatchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	onProgress := o.OnProgress
+	o.OnProgress = func(n *Notification) {
+		if onProgress != nil {
+			onProgress(n)
+		}
+		select {
+		case events <- WatchEvent{Notification: n}:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(events)
+		if n, err := c.WaitForDelivery(ctx, notificationID, &o); err != nil {
+			select {
+			case events <- WatchEvent{Notification: n, Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return events
+}
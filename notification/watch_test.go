@@ -0,0 +1,144 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
+
+// statsServer replies to each poll with the next of the given notification bodies,
+// repeating the last one.
+func statsServer(bodies ...string) (*httptest.Server, *atomic.Int32) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(polls.Add(1)) - 1
+		w.WriteHeader(200)
+		w.Write([]byte(bodies[min(i, len(bodies)-1)]))
+	}))
+	return server, &polls
+}
+
+func stats(sent, opened int) string {
+	return fmt.Sprintf(`{"id":7,"successfully_sent_count":%d,"opened_count":%d}`, sent, opened)
+}
+
+var fastWatch = &WatchOptions{InitialInterval: time.Millisecond, MaxInterval: 2 * time.Millisecond, StablePolls: 2}
+
+func TestWaitForDelivery(t *testing.T) {
+	server, polls := statsServer(stats(5, 0), stats(10, 1), stats(10, 2))
+	defer server.Close()
+
+	var progress []int64
+	opts := *fastWatch
+	opts.OnProgress = func(n *Notification) { progress = append(progress, n.OpenedCount) }
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	n, err := c.WaitForDelivery(context.Background(), 7, &opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if n.SuccessfullySent != 10 || n.OpenedCount != 2 {
+		t.Errorf("unexpected notification %+v", n)
+	}
+	if polls.Load() != 5 || len(progress) != 5 {
+		t.Errorf("expected 5 polls, got %d (%v)", polls.Load(), progress)
+	}
+}
+
+func TestWaitForDeliveryCancelled(t *testing.T) {
+	server, polls := statsServer(stats(0, 0), `{"id":7,"cancelled":true}`)
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	n, err := c.WaitForDelivery(context.Background(), 7, fastWatch)
+	if err != nil || !n.Cancelled || polls.Load() != 2 {
+		t.Errorf("expected to stop at the cancellation, got %+v, %v after %d polls", n, err, polls.Load())
+	}
+}
+
+func TestWaitForDeliveryDeadline(t *testing.T) {
+	sendAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server, _ := statsServer(`{"id":7,"scheduled":true,"send_at":"` + sendAt + `"}`)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	n, err := c.WaitForDelivery(ctx, 7, fastWatch)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if n == nil || !n.Scheduled {
+		t.Errorf("expected the last notification, got %+v", n)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server, _ := statsServer(stats(1, 0), stats(2, 0))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	var events []WatchEvent
+	for event := range c.Watch(context.Background(), 7, fastWatch) {
+		events = append(events, event)
+	}
+	if len(events) != 4 || events[0].Notification.SuccessfullySent != 1 || events[3].Notification.SuccessfullySent != 2 || events[3].Err != nil {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestWatchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer server.Close()
+
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	var events []WatchEvent
+	for event := range c.Watch(context.Background(), 7, fastWatch) {
+		events = append(events, event)
+	}
+	if len(events) != 1 || !errors.Is(events[0].Err, pushpad.ErrNotFound) {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestWatchCancel(t *testing.T) {
+	server, _ := statsServer(stats(1, 0), stats(2, 0), stats(3, 0), stats(4, 0))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewClient(pushpad.NewClient("TOKEN", 123, pushpad.WithBaseURL(server.URL)))
+	events := c.Watch(ctx, 7, &WatchOptions{InitialInterval: time.Millisecond, StablePolls: 100})
+	if event := <-events; event.Err != nil {
+		t.Fatalf("expected no error, got %s", event.Err)
+	}
+	cancel()
+
+	// let the watch goroutine stop without receiving, then expect at most
+	// the buffered event before the channel is closed
+	time.Sleep(50 * time.Millisecond)
+	pending := 0
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				if pending > 1 {
+					t.Errorf("expected at most 1 pending event, got %d", pending)
+				}
+				return
+			}
+			pending++
+		case <-timeout:
+			t.Fatal("expected the channel to be closed after cancelling")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package pushpad
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListMeta describes the pagination of a list response.
+type ListMeta struct {
+	// TotalCount is the total number of items, from the X-Total-Count header.
+	TotalCount int64
+	// Page is the number of the returned page, starting from 1.
+	Page int64
+	// PerPage is the page size, when known.
+	PerPage int64
+	// NextPage, PrevPage and LastPage are the numbers of the adjacent and last
+	// pages, or 0 when there is no such page or it is unknown.
+	NextPage int64
+	PrevPage int64
+	LastPage int64
+	// RateLimit holds the raw rate limit headers of the response
+	// (RateLimit-*, X-RateLimit-* and Retry-After).
+	RateLimit http.Header
+}
+
+// ParseListMeta extracts the pagination metadata from a list response. It
+// reads the Link header when present, and otherwise derives the adjacent
+// pages from X-Total-Count and the page size.
+func ParseListMeta(res *http.Response) ListMeta {
+	meta := ListMeta{Page: 1, RateLimit: http.Header{}}
+	if res == nil {
+		return meta
+	}
+
+	if res.Request != nil {
+		query := res.Request.URL.Query()
+		meta.Page = parsePositive(query.Get("page"), 1)
+		meta.PerPage = parsePositive(query.Get("per_page"), 0)
+	}
+	meta.Page = parsePositive(res.Header.Get("X-Page"), meta.Page)
+	meta.PerPage = parsePositive(res.Header.Get("X-Per-Page"), meta.PerPage)
+
+	totalCountHeader := res.Header.Get("X-Total-Count")
+	meta.TotalCount = parsePositive(totalCountHeader, 0)
+
+	if links := parseLinkHeader(res.Header.Values("Link")); len(links) > 0 {
+		meta.NextPage = links["next"]
+		meta.PrevPage = links["prev"]
+		meta.LastPage = links["last"]
+	} else {
+		meta.LastPage = parsePositive(res.Header.Get("X-Total-Pages"), 0)
+		if meta.LastPage == 0 && totalCountHeader != "" && meta.PerPage > 0 {
+			meta.LastPage = (meta.TotalCount + meta.PerPage - 1) / meta.PerPage
+		}
+		if meta.LastPage > 0 && meta.Page < meta.LastPage {
+			meta.NextPage = meta.Page + 1
+		}
+		if meta.Page > 1 {
+			meta.PrevPage = meta.Page - 1
+		}
+	}
+
+	for key, values := range res.Header {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "ratelimit") || strings.HasPrefix(lower, "x-ratelimit") || lower == "retry-after" {
+			meta.RateLimit[key] = values
+		}
+	}
+
+	return meta
+}
+
+// parseLinkHeader returns the page numbers of the relations in a Link header,
+// such as <https://pushpad.xyz/api/v1/projects/1/subscriptions?page=2>; rel="next".
+func parseLinkHeader(values []string) map[string]int64 {
+	pages := map[string]int64{}
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			u, err := url.Parse(target)
+			if err != nil {
+				continue
+			}
+			page := parsePositive(u.Query().Get("page"), 0)
+			if page == 0 {
+				continue
+			}
+			for _, param := range parts[1:] {
+				name, rel, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || name != "rel" {
+					continue
+				}
+				for _, r := range strings.Fields(strings.Trim(rel, `"`)) {
+					pages[r] = page
+				}
+			}
+		}
+	}
+	return pages
+}
+
+func parsePositive(value string, fallback int64) int64 {
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return fallback
+	}
+	return parsed
+}
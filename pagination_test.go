@@ -0,0 +1,53 @@
+package pushpad
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseListMetaWithLinkHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://pushpad.xyz/api/v1/projects/1/subscriptions?page=3&per_page=10", nil)
+	res := &http.Response{Request: req, Header: http.Header{}}
+	res.Header.Set("X-Total-Count", "395")
+	res.Header.Add("Link", `<https://pushpad.xyz/api/v1/projects/1/subscriptions?page=4&per_page=10>; rel="next", <https://pushpad.xyz/api/v1/projects/1/subscriptions?page=2&per_page=10>; rel="prev"`)
+	res.Header.Add("Link", `<https://pushpad.xyz/api/v1/projects/1/subscriptions?page=40&per_page=10>; rel="last"`)
+	res.Header.Set("X-RateLimit-Remaining", "99")
+	res.Header.Set("Content-Type", "application/json")
+
+	meta := ParseListMeta(res)
+	if meta.TotalCount != 395 {
+		t.Errorf("expected total count 395, got %d", meta.TotalCount)
+	}
+	if meta.Page != 3 || meta.PerPage != 10 {
+		t.Errorf("expected page 3 with 10 per page, got %d with %d", meta.Page, meta.PerPage)
+	}
+	if meta.NextPage != 4 || meta.PrevPage != 2 || meta.LastPage != 40 {
+		t.Errorf("expected next 4, prev 2, last 40, got %d, %d, %d", meta.NextPage, meta.PrevPage, meta.LastPage)
+	}
+	if meta.RateLimit.Get("X-RateLimit-Remaining") != "99" {
+		t.Errorf("expected rate limit header, got %v", meta.RateLimit)
+	}
+	if meta.RateLimit.Get("Content-Type") != "" {
+		t.Errorf("expected only rate limit headers, got %v", meta.RateLimit)
+	}
+}
+
+func TestParseListMetaFromTotalCount(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://pushpad.xyz/api/v1/projects/1/subscriptions?per_page=20", nil)
+	res := &http.Response{Request: req, Header: http.Header{}}
+	res.Header.Set("X-Total-Count", "45")
+
+	meta := ParseListMeta(res)
+	if meta.Page != 1 || meta.NextPage != 2 || meta.PrevPage != 0 || meta.LastPage != 3 {
+		t.Errorf("expected page 1 of 3 with next 2, got %+v", meta)
+	}
+}
+
+func TestParseListMetaWithoutHeaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://pushpad.xyz/api/v1/projects/1/notifications?page=2", nil)
+	meta := ParseListMeta(&http.Response{Request: req, Header: http.Header{}})
+	if meta.Page != 2 || meta.PrevPage != 1 || meta.NextPage != 0 || meta.LastPage != 0 || meta.TotalCount != 0 {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+}
@@ -1,44 +1,121 @@
 package project
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pushpad/pushpad-go"
 )
 
+// Client performs project API calls with a pushpad.Client.
+type Client struct {
+	backend *pushpad.Client
+}
+
+// NewClient returns a project client that uses the given pushpad.Client.
+// A nil backend uses pushpad.DefaultClient().
+func NewClient(backend *pushpad.Client) *Client {
+	return &Client{backend: backend}
+}
+
+var defaultClient = &Client{}
+
+func (c *Client) api() *pushpad.Client {
+	if c.backend != nil {
+		return c.backend
+	}
+	return pushpad.DefaultClient()
+}
+
 func List(params *ProjectListParams) ([]Project, error) {
+	return defaultClient.List(params)
+}
+
+func ListWithContext(ctx context.Context, params *ProjectListParams) ([]Project, error) {
+	return defaultClient.ListWithContext(ctx, params)
+}
+
+func Create(params *ProjectCreateParams) (*Project, error) {
+	return defaultClient.Create(params)
+}
+
+func CreateWithContext(ctx context.Context, params *ProjectCreateParams) (*Project, error) {
+	return defaultClient.CreateWithContext(ctx, params)
+}
+
+func Get(projectID int64, params *ProjectGetParams) (*Project, error) {
+	return defaultClient.Get(projectID, params)
+}
+
+func GetWithContext(ctx context.Context, projectID int64, params *ProjectGetParams) (*Project, error) {
+	return defaultClient.GetWithContext(ctx, projectID, params)
+}
+
+func Update(projectID int64, params *ProjectUpdateParams) (*Project, error) {
+	return defaultClient.Update(projectID, params)
+}
+
+func UpdateWithContext(ctx context.Context, projectID int64, params *ProjectUpdateParams) (*Project, error) {
+	return defaultClient.UpdateWithContext(ctx, projectID, params)
+}
+
+func Delete(projectID int64, params *ProjectDeleteParams) error {
+	return defaultClient.Delete(projectID, params)
+}
+
+func DeleteWithContext(ctx context.Context, projectID int64, params *ProjectDeleteParams) error {
+	return defaultClient.DeleteWithContext(ctx, projectID, params)
+}
+
+func (c *Client) List(params *ProjectListParams) ([]Project, error) {
+	return c.ListWithContext(context.Background(), params)
+}
+
+func (c *Client) ListWithContext(ctx context.Context, params *ProjectListParams) ([]Project, error) {
 	var projects []Project
-	_, err := pushpad.DoRequest("GET", "/projects", nil, nil, []int{200}, &projects)
+	_, err := c.api().DoRequestWithContext(ctx, "GET", "/projects", nil, nil, []int{200}, &projects, pushpad.WithOperation("project.List"))
 	return projects, err
 }
 
-func Create(params *ProjectCreateParams) (*Project, error) {
+func (c *Client) Create(params *ProjectCreateParams) (*Project, error) {
+	return c.CreateWithContext(context.Background(), params)
+}
+
+func (c *Client) CreateWithContext(ctx context.Context, params *ProjectCreateParams) (*Project, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
 
 	var created Project
-	_, err := pushpad.DoRequest("POST", "/projects", nil, params, []int{201}, &created)
+	_, err := c.api().DoRequestWithContext(ctx, "POST", "/projects", nil, params, []int{201}, &created, pushpad.WithOperation("project.Create"))
 	if err != nil {
 		return nil, err
 	}
 	return &created, nil
 }
 
-func Get(projectID int64, params *ProjectGetParams) (*Project, error) {
+func (c *Client) Get(projectID int64, params *ProjectGetParams) (*Project, error) {
+	return c.GetWithContext(context.Background(), projectID, params)
+}
+
+func (c *Client) GetWithContext(ctx context.Context, projectID int64, params *ProjectGetParams) (*Project, error) {
 	if projectID == 0 {
 		return nil, fmt.Errorf("pushpad: project ID is required")
 	}
 
 	var project Project
-	_, err := pushpad.DoRequest("GET", fmt.Sprintf("/projects/%d", projectID), nil, nil, []int{200}, &project)
+	_, err := c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/projects/%d", projectID), nil, nil, []int{200}, &project, pushpad.WithOperation("project.Get"))
 	if err != nil {
 		return nil, err
 	}
 	return &project, nil
 }
 
-func Update(projectID int64, params *ProjectUpdateParams) (*Project, error) {
+func (c *Client) Update(projectID int64, params *ProjectUpdateParams) (*Project, error) {
+	return c.UpdateWithContext(context.Background(), projectID, params)
+}
+
+func (c *Client) UpdateWithContext(ctx context.Context, projectID int64, params *ProjectUpdateParams) (*Project, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
@@ -47,17 +124,21 @@ func Update(projectID int64, params *ProjectUpdateParams) (*Project, error) {
 	}
 
 	var project Project
-	_, err := pushpad.DoRequest("PATCH", fmt.Sprintf("/projects/%d", projectID), nil, params, []int{200}, &project)
+	_, err := c.api().DoRequestWithContext(ctx, "PATCH", fmt.Sprintf("/projects/%d", projectID), nil, params, []int{200}, &project, pushpad.WithOperation("project.Update"))
 	if err != nil {
 		return nil, err
 	}
 	return &project, nil
 }
 
-func Delete(projectID int64, params *ProjectDeleteParams) error {
+func (c *Client) Delete(projectID int64, params *ProjectDeleteParams) error {
+	return c.DeleteWithContext(context.Background(), projectID, params)
+}
+
+func (c *Client) DeleteWithContext(ctx context.Context, projectID int64, params *ProjectDeleteParams) error {
 	if projectID == 0 {
 		return fmt.Errorf("pushpad: project ID is required")
 	}
-	_, err := pushpad.DoRequest("DELETE", fmt.Sprintf("/projects/%d", projectID), nil, nil, []int{202}, nil)
+	_, err := c.api().DoRequestWithContext(ctx, "DELETE", fmt.Sprintf("/projects/%d", projectID), nil, nil, []int{202}, nil, pushpad.WithOperation("project.Delete"))
 	return err
 }
@@ -0,0 +1,20 @@
+package project
+
+import "context"
+
+// ProjectService is the set of project API calls, implemented by *Client.
+// Depend on it instead of *Client to substitute a fake in tests.
+type ProjectService interface {
+	List(params *ProjectListParams) ([]Project, error)
+	ListWithContext(ctx context.Context, params *ProjectListParams) ([]Project, error)
+	Create(params *ProjectCreateParams) (*Project, error)
+	CreateWithContext(ctx context.Context, params *ProjectCreateParams) (*Project, error)
+	Get(projectID int64, params *ProjectGetParams) (*Project, error)
+	GetWithContext(ctx context.Context, projectID int64, params *ProjectGetParams) (*Project, error)
+	Update(projectID int64, params *ProjectUpdateParams) (*Project, error)
+	UpdateWithContext(ctx context.Context, projectID int64, params *ProjectUpdateParams) (*Project, error)
+	Delete(projectID int64, params *ProjectDeleteParams) error
+	DeleteWithContext(ctx context.Context, projectID int64, params *ProjectDeleteParams) error
+}
+
+var _ ProjectService = (*Client)(nil)
@@ -1,10 +1,25 @@
 package pushpad
 
-var pushpadAuthToken string
-var pushpadProjectID int64
+import "sync/atomic"
+
+var defaultClient atomic.Pointer[Client]
+
+func init() {
+	defaultClient.Store(NewClient("", 0))
+}
 
 // Configure sets the global credentials for API calls.
+// It is safe to call while requests are in progress.
 func Configure(authToken string, projectID int64) {
-	pushpadAuthToken = authToken
-	pushpadProjectID = projectID
+	DefaultClient().SetCredentials(authToken, projectID)
+}
+
+// DefaultClient returns the client used by the package-level functions.
+func DefaultClient() *Client {
+	return defaultClient.Load()
+}
+
+// SetDefaultClient replaces the client used by the package-level functions.
+func SetDefaultClient(c *Client) {
+	defaultClient.Store(c)
 }
@@ -7,11 +7,11 @@ import (
 func TestConfigure(t *testing.T) {
 	Configure("AUTH_TOKEN", 123)
 
-	if pushpadAuthToken != "AUTH_TOKEN" {
-		t.Errorf("got %q instead of AUTH_TOKEN", pushpadAuthToken)
+	if DefaultClient().AuthToken() != "AUTH_TOKEN" {
+		t.Errorf("got %q instead of AUTH_TOKEN", DefaultClient().AuthToken())
 	}
 
-	if pushpadProjectID != 123 {
-		t.Errorf("got %d instead of project ID 123", pushpadProjectID)
+	if DefaultClient().ProjectID() != 123 {
+		t.Errorf("got %d instead of project ID 123", DefaultClient().ProjectID())
 	}
 }
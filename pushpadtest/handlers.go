@@ -0,0 +1,524 @@
+package pushpadtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/notification"
+	"github.com/pushpad/pushpad-go/project"
+	"github.com/pushpad/pushpad-go/sender"
+	"github.com/pushpad/pushpad-go/subscription"
+	"github.com/pushpad/pushpad-go/tags"
+)
+
+const (
+	defaultPerPage = 25
+	maxPerPage     = 1000
+	defaultTTL     = 604800
+)
+
+func (s *Server) listSenders(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Senders())
+}
+
+func (s *Server) createSender(w http.ResponseWriter, r *http.Request) {
+	var params sender.SenderCreateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if blank(params.Name) {
+		writeErrors(w, map[string][]string{"name": {"can't be blank"}})
+		return
+	}
+	snd := sender.Sender{
+		Name:            *params.Name,
+		VAPIDPrivateKey: valueOr(params.VAPIDPrivateKey, randomKey()),
+		VAPIDPublicKey:  valueOr(params.VAPIDPublicKey, randomKey()),
+	}
+	writeJSON(w, http.StatusCreated, s.AddSender(snd))
+}
+
+func (s *Server) getSender(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snd, ok := find(w, r, "id", s.senders)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, snd)
+}
+
+func (s *Server) updateSender(w http.ResponseWriter, r *http.Request) {
+	var params sender.SenderUpdateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snd, ok := find(w, r, "id", s.senders)
+	if !ok {
+		return
+	}
+	if params.Name != nil {
+		snd.Name = *params.Name
+	}
+	writeJSON(w, http.StatusOK, snd)
+}
+
+func (s *Server) deleteSender(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snd, ok := find(w, r, "id", s.senders)
+	if !ok {
+		return
+	}
+	delete(s.senders, snd.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Projects())
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var params project.ProjectCreateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	errs := map[string][]string{}
+	if blank(params.Name) {
+		errs["name"] = append(errs["name"], "can't be blank")
+	}
+	if blank(params.Website) {
+		errs["website"] = append(errs["website"], "can't be blank")
+	}
+	s.mu.Lock()
+	_, senderExists := s.senders[valueOr(params.SenderID, 0)]
+	s.mu.Unlock()
+	if !senderExists {
+		errs["sender_id"] = append(errs["sender_id"], "is invalid")
+	}
+	if len(errs) > 0 {
+		writeErrors(w, errs)
+		return
+	}
+
+	p := project.Project{
+		SenderID:                     *params.SenderID,
+		Name:                         *params.Name,
+		Website:                      *params.Website,
+		IconURL:                      valueOr(params.IconURL, ""),
+		BadgeURL:                     valueOr(params.BadgeURL, ""),
+		NotificationsTTL:             valueOr(params.NotificationsTTL, defaultTTL),
+		NotificationsRequireInteract: valueOr(params.NotificationsRequireInteract, false),
+		NotificationsSilent:          valueOr(params.NotificationsSilent, false),
+	}
+	writeJSON(w, http.StatusCreated, s.AddProject(p))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := find(w, r, "id", s.projects)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var params project.ProjectUpdateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := find(w, r, "id", s.projects)
+	if !ok {
+		return
+	}
+	set(&p.Name, params.Name)
+	set(&p.Website, params.Website)
+	set(&p.IconURL, params.IconURL)
+	set(&p.BadgeURL, params.BadgeURL)
+	set(&p.NotificationsTTL, params.NotificationsTTL)
+	set(&p.NotificationsRequireInteract, params.NotificationsRequireInteract)
+	set(&p.NotificationsSilent, params.NotificationsSilent)
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := find(w, r, "id", s.projects)
+	if !ok {
+		return
+	}
+	delete(s.projects, p.ID)
+	for id, sub := range s.subscriptions {
+		if sub.ProjectID == p.ID {
+			delete(s.subscriptions, id)
+		}
+	}
+	for id, n := range s.notifications {
+		if n.ProjectID == p.ID {
+			delete(s.notifications, id)
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	p, ok := find(w, r, "project", s.projects)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	uids, filterUIDs := query["uids[]"]
+	expr, err := tags.ParseList(query["tags[]"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	subscriptions := s.Subscriptions(p.ID)
+	subscriptions = slices.DeleteFunc(subscriptions, func(sub subscription.Subscription) bool {
+		return !matches(sub, uids, filterUIDs, expr)
+	})
+
+	if r.Method == http.MethodHead {
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(subscriptions)))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writePage(w, r, subscriptions, perPage(query))
+}
+
+func (s *Server) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var params subscription.SubscriptionCreateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	s.mu.Lock()
+	p, ok := find(w, r, "project", s.projects)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	if blank(params.Endpoint) {
+		writeErrors(w, map[string][]string{"endpoint": {"can't be blank"}})
+		return
+	}
+
+	sub := subscription.Subscription{
+		ProjectID: p.ID,
+		Endpoint:  *params.Endpoint,
+		P256DH:    valueOr(params.P256DH, ""),
+		Auth:      valueOr(params.Auth, ""),
+		UID:       valueOr(params.UID, ""),
+		Tags:      valueOr(params.Tags, []string{}),
+	}
+	writeJSON(w, http.StatusCreated, s.AddSubscription(sub))
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.findSubscription(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	var params subscription.SubscriptionUpdateParams
+	if !decode(w, r, &params) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.findSubscription(w, r)
+	if !ok {
+		return
+	}
+	set(&sub.UID, params.UID)
+	if params.Tags != nil {
+		sub.Tags = slices.Clone(*params.Tags)
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.findSubscription(w, r)
+	if !ok {
+		return
+	}
+	delete(s.subscriptions, sub.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findSubscription returns the subscription in the path, which must belong to
+// the project in the path. It must be called with s.mu held.
+func (s *Server) findSubscription(w http.ResponseWriter, r *http.Request) (*subscription.Subscription, bool) {
+	p, ok := find(w, r, "project", s.projects)
+	if !ok {
+		return nil, false
+	}
+	sub, ok := find(w, r, "id", s.subscriptions)
+	if !ok {
+		return nil, false
+	}
+	if sub.ProjectID != p.ID {
+		writeError(w, http.StatusNotFound, "Not found")
+		return nil, false
+	}
+	return sub, true
+}
+
+func (s *Server) listNotifications(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	p, ok := find(w, r, "project", s.projects)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	notifications := s.Notifications(p.ID)
+	slices.Reverse(notifications)
+	writePage(w, r, notifications, defaultPerPage)
+}
+
+func (s *Server) createNotification(w http.ResponseWriter, r *http.Request) {
+	var params notification.NotificationCreateParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := find(w, r, "project", s.projects)
+	if !ok {
+		return
+	}
+	key := r.Header.Get(pushpad.IdempotencyKeyHeader)
+	if key != "" {
+		key = strconv.FormatInt(p.ID, 10) + " " + key
+		if response, ok := s.idempotent[key]; ok {
+			writeJSON(w, http.StatusCreated, response)
+			return
+		}
+	}
+	errs := map[string][]string{}
+	if blank(params.Body) {
+		errs["body"] = append(errs["body"], "can't be blank")
+	}
+	expr, err := tags.ParseList(valueOr(params.Tags, nil))
+	if err != nil {
+		errs["tags"] = append(errs["tags"], "is invalid")
+	}
+	if len(errs) > 0 {
+		writeErrors(w, errs)
+		return
+	}
+
+	n := notification.Notification{
+		ID:                 s.newID(0),
+		ProjectID:          p.ID,
+		Title:              valueOr(params.Title, p.Name),
+		Body:               *params.Body,
+		TargetURL:          valueOr(params.TargetURL, p.Website),
+		IconURL:            valueOr(params.IconURL, p.IconURL),
+		BadgeURL:           valueOr(params.BadgeURL, p.BadgeURL),
+		ImageURL:           valueOr(params.ImageURL, ""),
+		TTL:                valueOr(params.TTL, p.NotificationsTTL),
+		RequireInteraction: valueOr(params.RequireInteraction, p.NotificationsRequireInteract),
+		Silent:             valueOr(params.Silent, p.NotificationsSilent),
+		Urgent:             valueOr(params.Urgent, false),
+		CustomData:         valueOr(params.CustomData, ""),
+		Starred:            valueOr(params.Starred, false),
+		CustomMetrics:      valueOr(params.CustomMetrics, []string{}),
+		UIDs:               valueOr(params.UIDs, nil),
+		Tags:               valueOr(params.Tags, nil),
+		CreatedAt:          now(),
+	}
+	for _, action := range valueOr(params.Actions, nil) {
+		n.Actions = append(n.Actions, notification.NotificationAction{
+			Title:     valueOr(action.Title, ""),
+			TargetURL: valueOr(action.TargetURL, ""),
+			Icon:      valueOr(action.Icon, ""),
+			Action:    valueOr(action.Action, ""),
+		})
+	}
+
+	response := notification.NotificationCreateResponse{ID: n.ID, UIDs: []string{}}
+	for _, sub := range s.subscriptions {
+		if sub.ProjectID != p.ID || !matches(*sub, n.UIDs, params.UIDs != nil, expr) {
+			continue
+		}
+		response.Scheduled++
+		if sub.UID != "" && params.UIDs != nil && !slices.Contains(response.UIDs, sub.UID) {
+			response.UIDs = append(response.UIDs, sub.UID)
+		}
+	}
+	slices.Sort(response.UIDs)
+	n.ScheduledCount = response.Scheduled
+	if params.SendAt != nil && params.SendAt.After(time.Now()) {
+		n.SendAt = params.SendAt.UTC()
+		n.Scheduled = true
+		response.SendAt = n.SendAt
+	} else {
+		n.SuccessfullySent = response.Scheduled
+	}
+
+	s.notifications[n.ID] = &n
+	if key != "" {
+		s.idempotent[key] = response
+	}
+	writeJSON(w, http.StatusCreated, response)
+}
+
+func (s *Server) getNotification(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := find(w, r, "id", s.notifications)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, n)
+}
+
+func (s *Server) cancelNotification(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := find(w, r, "id", s.notifications)
+	if !ok {
+		return
+	}
+	if !n.Scheduled || n.Cancelled {
+		writeError(w, http.StatusUnprocessableEntity, "Only scheduled notifications can be cancelled")
+		return
+	}
+	n.Cancelled = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// matches reports whether a subscription has one of uids, when filterUIDs is
+// set, and matches the tag expression, when it is not nil.
+func matches(sub subscription.Subscription, uids []string, filterUIDs bool, expr tags.Expr) bool {
+	if filterUIDs && !slices.Contains(uids, sub.UID) {
+		return false
+	}
+	return expr == nil || expr.Match(sub.Tags)
+}
+
+func perPage(query url.Values) int {
+	n, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || n <= 0 {
+		return defaultPerPage
+	}
+	return min(n, maxPerPage)
+}
+
+// writePage writes the requested page of items with the X-Total-Count and
+// Link headers.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T, perPage int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	lastPage := max((len(items)+perPage-1)/perPage, 1)
+
+	link := func(rel string, page int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+	links := []string{link("first", 1)}
+	if page > 1 {
+		links = append(links, link("prev", page-1))
+	}
+	if page < lastPage {
+		links = append(links, link("next", page+1))
+	}
+	links = append(links, link("last", lastPage))
+	for _, l := range links {
+		w.Header().Add("Link", l)
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+// find returns the item with the ID in the path wildcard name, or writes a
+// 404 response.
+func find[T any](w http.ResponseWriter, r *http.Request, name string, items map[int64]*T) (*T, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err == nil {
+		if item, ok := items[id]; ok {
+			return item, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not found")
+	return nil, false
+}
+
+// decode reads the JSON body of the request into v, or writes a 400 response.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeErrors(w http.ResponseWriter, errs map[string][]string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs})
+}
+
+func blank(value *string) bool {
+	return value == nil || *value == ""
+}
+
+func valueOr[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func set[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func randomKey() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
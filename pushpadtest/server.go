@@ -0,0 +1,225 @@
+// Package pushpadtest provides an in-memory fake of the Pushpad API for
+// integration tests.
+//
+// The server implements the projects, senders, subscriptions and notifications
+// endpoints covered by this library, so tests can exercise real HTTP requests
+// without network access:
+//
+//	server := pushpadtest.NewServer()
+//	defer server.Close()
+//
+//	p := server.AddProject(project.Project{Name: "Test"})
+//	api := client.NewFromClient(server.Client(p.ID))
+//	api.Notifications.Create(&notification.NotificationCreateParams{Body: pushpad.String("Hello")})
+//
+//	sent := server.Notifications(p.ID)
+package pushpadtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/notification"
+	"github.com/pushpad/pushpad-go/project"
+	"github.com/pushpad/pushpad-go/sender"
+	"github.com/pushpad/pushpad-go/subscription"
+)
+
+// AuthToken is the token accepted by the server.
+const AuthToken = "pushpadtest-token"
+
+// BasePath is the path of the API on the server.
+const BasePath = "/api/v1"
+
+// Server is a fake Pushpad API that keeps its state in memory. It is safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	lastID        int64
+	projects      map[int64]*project.Project
+	senders       map[int64]*sender.Sender
+	subscriptions map[int64]*subscription.Subscription
+	notifications map[int64]*notification.Notification
+	// idempotent holds the create notification responses by Idempotency-Key.
+	idempotent map[string]notification.NotificationCreateResponse
+}
+
+// NewServer starts a server with no data. The caller must call Close when
+// finished.
+func NewServer() *Server {
+	s := &Server{
+		projects:      map[int64]*project.Project{},
+		senders:       map[int64]*sender.Sender{},
+		subscriptions: map[int64]*subscription.Subscription{},
+		notifications: map[int64]*notification.Notification{},
+		idempotent:    map[string]notification.NotificationCreateResponse{},
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// BaseURL returns the base URL to configure clients with.
+func (s *Server) BaseURL() string {
+	return s.URL + BasePath
+}
+
+// Client returns a client authenticated for the server, with projectID as
+// its default project.
+func (s *Server) Client(projectID int64, opts ...pushpad.ClientOption) *pushpad.Client {
+	opts = append([]pushpad.ClientOption{pushpad.WithBaseURL(s.BaseURL())}, opts...)
+	return pushpad.NewClient(AuthToken, projectID, opts...)
+}
+
+// AddSender stores a sender and returns it with its ID and creation time set.
+func (s *Server) AddSender(snd sender.Sender) sender.Sender {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snd.ID = s.newID(snd.ID)
+	if snd.CreatedAt.IsZero() {
+		snd.CreatedAt = now()
+	}
+	s.senders[snd.ID] = &snd
+	return snd
+}
+
+// AddProject stores a project and returns it with its ID and creation time
+// set. A sender is added for the project when SenderID is 0.
+func (s *Server) AddProject(p project.Project) project.Project {
+	if p.SenderID == 0 {
+		p.SenderID = s.AddSender(sender.Sender{Name: p.Name}).ID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.ID = s.newID(p.ID)
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now()
+	}
+	s.projects[p.ID] = &p
+	return p
+}
+
+// AddSubscription stores a subscription and returns it with its ID and
+// creation time set. ProjectID must be set.
+func (s *Server) AddSubscription(sub subscription.Subscription) subscription.Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub.ID = s.newID(sub.ID)
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = now()
+	}
+	sub.Tags = slices.Clone(sub.Tags)
+	s.subscriptions[sub.ID] = &sub
+	return sub
+}
+
+// Senders returns the senders, ordered by ID.
+func (s *Server) Senders() []sender.Sender {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.senders, func(*sender.Sender) bool { return true })
+}
+
+// Projects returns the projects, ordered by ID.
+func (s *Server) Projects() []project.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.projects, func(*project.Project) bool { return true })
+}
+
+// Subscriptions returns the subscriptions of a project, ordered by ID.
+func (s *Server) Subscriptions(projectID int64) []subscription.Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.subscriptions, func(sub *subscription.Subscription) bool { return sub.ProjectID == projectID })
+}
+
+// Notifications returns the notifications of a project, ordered by ID.
+func (s *Server) Notifications(projectID int64) []notification.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return values(s.notifications, func(n *notification.Notification) bool { return n.ProjectID == projectID })
+}
+
+// Reset removes all the data.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.projects)
+	clear(s.senders)
+	clear(s.subscriptions)
+	clear(s.notifications)
+	clear(s.idempotent)
+}
+
+// newID returns id, or the next free ID when id is 0. It must be called with
+// s.mu held.
+func (s *Server) newID(id int64) int64 {
+	if id == 0 {
+		s.lastID++
+		return s.lastID
+	}
+	s.lastID = max(s.lastID, id)
+	return id
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// values returns copies of the items that match keep, ordered by ID.
+func values[T any](items map[int64]*T, keep func(*T) bool) []T {
+	ids := make([]int64, 0, len(items))
+	for id, item := range items {
+		if keep(item) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *items[id])
+	}
+	return result
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/senders", s.listSenders)
+	mux.HandleFunc("POST "+BasePath+"/senders", s.createSender)
+	mux.HandleFunc("GET "+BasePath+"/senders/{id}", s.getSender)
+	mux.HandleFunc("PATCH "+BasePath+"/senders/{id}", s.updateSender)
+	mux.HandleFunc("DELETE "+BasePath+"/senders/{id}", s.deleteSender)
+
+	mux.HandleFunc("GET "+BasePath+"/projects", s.listProjects)
+	mux.HandleFunc("POST "+BasePath+"/projects", s.createProject)
+	mux.HandleFunc("GET "+BasePath+"/projects/{id}", s.getProject)
+	mux.HandleFunc("PATCH "+BasePath+"/projects/{id}", s.updateProject)
+	mux.HandleFunc("DELETE "+BasePath+"/projects/{id}", s.deleteProject)
+
+	// GET patterns also match HEAD requests, used to count subscriptions.
+	mux.HandleFunc("GET "+BasePath+"/projects/{project}/subscriptions", s.listSubscriptions)
+	mux.HandleFunc("POST "+BasePath+"/projects/{project}/subscriptions", s.createSubscription)
+	mux.HandleFunc("GET "+BasePath+"/projects/{project}/subscriptions/{id}", s.getSubscription)
+	mux.HandleFunc("PATCH "+BasePath+"/projects/{project}/subscriptions/{id}", s.updateSubscription)
+	mux.HandleFunc("DELETE "+BasePath+"/projects/{project}/subscriptions/{id}", s.deleteSubscription)
+
+	mux.HandleFunc("GET "+BasePath+"/projects/{project}/notifications", s.listNotifications)
+	mux.HandleFunc("POST "+BasePath+"/projects/{project}/notifications", s.createNotification)
+	mux.HandleFunc("GET "+BasePath+"/notifications/{id}", s.getNotification)
+	mux.HandleFunc("DELETE "+BasePath+"/notifications/{id}/cancel", s.cancelNotification)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+AuthToken {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,243 @@
+package pushpadtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+	"github.com/pushpad/pushpad-go/client"
+	"github.com/pushpad/pushpad-go/notification"
+	"github.com/pushpad/pushpad-go/project"
+	"github.com/pushpad/pushpad-go/sender"
+	"github.com/pushpad/pushpad-go/subscription"
+)
+
+func TestProjectsAndSenders(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	api := client.NewFromClient(server.Client(0))
+
+	snd, err := api.Senders.Create(&sender.SenderCreateParams{Name: pushpad.String("Sender")})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if snd.ID == 0 || snd.VAPIDPublicKey == "" || snd.VAPIDPrivateKey == "" {
+		t.Errorf("unexpected sender %+v", snd)
+	}
+
+	p, err := api.Projects.Create(&project.ProjectCreateParams{
+		SenderID: pushpad.Int64(snd.ID),
+		Name:     pushpad.String("Project"),
+		Website:  pushpad.String("https://example.com"),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	p, err = api.Projects.Update(p.ID, &project.ProjectUpdateParams{Name: pushpad.String("Renamed")})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if p.Name != "Renamed" || p.Website != "https://example.com" || p.NotificationsTTL != defaultTTL {
+		t.Errorf("unexpected project %+v", p)
+	}
+	if projects := server.Projects(); len(projects) != 1 || projects[0].Name != "Renamed" {
+		t.Errorf("unexpected projects %+v", projects)
+	}
+
+	_, err = api.Projects.Create(&project.ProjectCreateParams{Name: pushpad.String("Invalid")})
+	var apiErr *pushpad.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, pushpad.ErrValidation) || len(apiErr.Errors["website"]) != 1 || len(apiErr.Errors["sender_id"]) != 1 {
+		t.Errorf("expected validation errors, got %v", err)
+	}
+
+	if err := api.Projects.Delete(p.ID, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, err := api.Projects.Get(p.ID, nil); !errors.Is(err, pushpad.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if err := api.Senders.Delete(snd.ID, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if senders := server.Senders(); len(senders) != 0 {
+		t.Errorf("expected no senders, got %+v", senders)
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	p := server.AddProject(project.Project{Name: "Project"})
+	api := client.NewFromClient(server.Client(p.ID))
+
+	for i, uid := range []string{"a", "b", "c", "d", "e"} {
+		tags := []string{"all"}
+		if i%2 == 0 {
+			tags = append(tags, "even")
+		}
+		_, err := api.Subscriptions.Create(&subscription.SubscriptionCreateParams{
+			Endpoint: pushpad.String("https://push.example.com/" + uid),
+			UID:      pushpad.String(uid),
+			Tags:     pushpad.StringSlice(tags),
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+
+	count, err := api.Subscriptions.Count(&subscription.SubscriptionCountParams{Tags: pushpad.StringSlice([]string{"even"})})
+	if err != nil || count != 3 {
+		t.Errorf("expected 3 subscriptions, got %d (%v)", count, err)
+	}
+	count, err = api.Subscriptions.Count(&subscription.SubscriptionCountParams{Tags: pushpad.StringSlice([]string{"all && !even", "missing"})})
+	if err != nil || count != 2 {
+		t.Errorf("expected 2 subscriptions, got %d (%v)", count, err)
+	}
+	if _, err := api.Subscriptions.Count(&subscription.SubscriptionCountParams{Tags: pushpad.StringSlice([]string{"all &&"})}); !errors.Is(err, pushpad.ErrBadRequest) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+	count, err = api.Subscriptions.Count(&subscription.SubscriptionCountParams{UIDs: pushpad.StringSlice([]string{"a", "b", "z"})})
+	if err != nil || count != 2 {
+		t.Errorf("expected 2 subscriptions, got %d (%v)", count, err)
+	}
+
+	result, err := api.Subscriptions.ListPage(&subscription.SubscriptionListParams{Page: pushpad.Int64(2), PerPage: pushpad.Int64(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(result.Subscriptions) != 2 || result.Subscriptions[0].UID != "c" || result.TotalCount != 5 || result.NextPage != 3 || result.PrevPage != 1 || result.LastPage != 3 {
+		t.Errorf("unexpected page %+v", result)
+	}
+
+	var uids []string
+	for sub, err := range api.Subscriptions.All(context.Background(), &subscription.SubscriptionListParams{PerPage: pushpad.Int64(2)}) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		uids = append(uids, sub.UID)
+	}
+	if len(uids) != 5 {
+		t.Errorf("expected 5 subscriptions, got %v", uids)
+	}
+
+	first := server.Subscriptions(p.ID)[0]
+	updated, err := api.Subscriptions.Update(first.ID, &subscription.SubscriptionUpdateParams{Tags: pushpad.StringSlice([]string{"vip"})})
+	if err != nil || updated.UID != "a" || len(updated.Tags) != 1 || updated.Tags[0] != "vip" {
+		t.Errorf("unexpected subscription %+v (%v)", updated, err)
+	}
+	if err := api.Subscriptions.Delete(first.ID, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, err := api.Subscriptions.Get(first.ID, nil); !errors.Is(err, pushpad.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := api.Subscriptions.Get(first.ID+1, &subscription.SubscriptionGetParams{ProjectID: pushpad.Int64(p.ID + 100)}); !errors.Is(err, pushpad.ErrNotFound) {
+		t.Errorf("expected not found error for another project, got %v", err)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	p := server.AddProject(project.Project{Name: "Project", Website: "https://example.com"})
+	server.AddSubscription(subscription.Subscription{ProjectID: p.ID, UID: "user1", Tags: []string{"news"}})
+	server.AddSubscription(subscription.Subscription{ProjectID: p.ID, UID: "user2"})
+	api := client.NewFromClient(server.Client(p.ID))
+
+	res, err := api.Notifications.Create(&notification.NotificationCreateParams{
+		Body: pushpad.String("Hello"),
+		UIDs: pushpad.StringSlice([]string{"user1", "user3"}),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if res.Scheduled != 1 || len(res.UIDs) != 1 || res.UIDs[0] != "user1" {
+		t.Errorf("unexpected response %+v", res)
+	}
+
+	n, err := api.Notifications.Get(res.ID, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if n.Title != "Project" || n.Body != "Hello" || n.TargetURL != "https://example.com" || n.SuccessfullySent != 1 || n.Scheduled {
+		t.Errorf("unexpected notification %+v", n)
+	}
+	if err := api.Notifications.Cancel(res.ID, nil); !errors.Is(err, pushpad.ErrValidation) {
+		t.Errorf("expected a sent notification not to be cancellable, got %v", err)
+	}
+
+	scheduled, err := api.Notifications.Create(&notification.NotificationCreateParams{
+		Body:   pushpad.String("Later"),
+		SendAt: pushpad.Time(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if scheduled.Scheduled != 2 || scheduled.SendAt.IsZero() {
+		t.Errorf("unexpected response %+v", scheduled)
+	}
+	if err := api.Notifications.Cancel(scheduled.ID, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	notifications, err := api.Notifications.List(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(notifications) != 2 || notifications[0].ID != scheduled.ID || !notifications[0].Cancelled {
+		t.Errorf("unexpected notifications %+v", notifications)
+	}
+	if stored := server.Notifications(p.ID); len(stored) != 2 || stored[0].ID != res.ID {
+		t.Errorf("unexpected stored notifications %+v", stored)
+	}
+
+	if _, err := api.Notifications.Create(&notification.NotificationCreateParams{}); !errors.Is(err, pushpad.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestNotificationIdempotencyKey(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	p := server.AddProject(project.Project{Name: "Project"})
+	api := client.NewFromClient(server.Client(p.ID))
+
+	params := &notification.NotificationCreateParams{Body: pushpad.String("Hello"), IdempotencyKey: pushpad.String("key")}
+	first, err := api.Notifications.Create(params)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	// a new client has no cached response, so the server deduplicates
+	api = client.NewFromClient(server.Client(p.ID))
+	second, err := api.Notifications.Create(params)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if first.ID != second.ID || len(server.Notifications(p.ID)) != 1 {
+		t.Errorf("expected a single notification, got %d and %d", first.ID, second.ID)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	c := pushpad.NewClient("WRONG", 0, pushpad.WithBaseURL(server.BaseURL()))
+	if _, err := project.NewClient(c).List(nil); !errors.Is(err, pushpad.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	p := server.AddProject(project.Project{Name: "Project"})
+	server.AddSubscription(subscription.Subscription{ProjectID: p.ID})
+
+	server.Reset()
+	if len(server.Projects()) != 0 || len(server.Senders()) != 0 || len(server.Subscriptions(p.ID)) != 0 {
+		t.Errorf("expected no data after reset")
+	}
+}
@@ -0,0 +1,118 @@
+package pushpad
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that limits the rate of requests. It is safe
+// for concurrent use and can be shared by several clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter that allows requestsPerSecond requests on
+// average, with bursts of up to burst requests. A burst lower than 1 is set to 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// reserve a token, going into debt if none is available
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if err := sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// EndpointClass groups the API endpoints that share a rate limit budget.
+type EndpointClass string
+
+// Endpoint classes, one for each resource of the API.
+const (
+	EndpointNotifications EndpointClass = "notifications"
+	EndpointSubscriptions EndpointClass = "subscriptions"
+	EndpointProjects      EndpointClass = "projects"
+	EndpointSenders       EndpointClass = "senders"
+)
+
+// EndpointClassOf returns the class of the endpoint at path.
+func EndpointClassOf(path string) EndpointClass {
+	switch {
+	case strings.Contains(path, "/subscriptions"):
+		return EndpointSubscriptions
+	case strings.Contains(path, "/notifications"):
+		return EndpointNotifications
+	case strings.HasPrefix(path, "/senders"):
+		return EndpointSenders
+	default:
+		return EndpointProjects
+	}
+}
+
+// WithRateLimiter makes every request of the client wait on the limiter.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithEndpointRateLimiter makes the requests to the endpoints of a class wait
+// on the limiter, in addition to the limiter set by WithRateLimiter.
+func WithEndpointRateLimiter(class EndpointClass, limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		if c.endpointLimiters == nil {
+			c.endpointLimiters = map[EndpointClass]*RateLimiter{}
+		}
+		c.endpointLimiters[class] = limiter
+	}
+}
+
+// waitRateLimit waits on the limiters that apply to path.
+func (c *Client) waitRateLimit(ctx context.Context, path string) error {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if limiter := c.endpointLimiters[EndpointClassOf(path)]; limiter != nil {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
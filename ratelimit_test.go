@@ -0,0 +1,83 @@
+package pushpad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Errorf("expected the burst not to wait")
+	}
+}
+
+func TestRateLimiterWaits(t *testing.T) {
+	l := NewRateLimiter(100, 1)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("expected about 30ms of waiting, got %s", elapsed)
+	}
+}
+
+func TestRateLimiterContextCancellation(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestEndpointClassOf(t *testing.T) {
+	tests := map[string]EndpointClass{
+		"/projects/1/notifications":   EndpointNotifications,
+		"/notifications/5/cancel":     EndpointNotifications,
+		"/projects/1/subscriptions/3": EndpointSubscriptions,
+		"/projects/1":                 EndpointProjects,
+		"/senders/2":                  EndpointSenders,
+	}
+	for path, want := range tests {
+		if got := EndpointClassOf(path); got != want {
+			t.Errorf("%s: got %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestClientWaitsOnEndpointRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 1, WithBaseURL(server.URL), WithEndpointRateLimiter(EndpointSubscriptions, NewRateLimiter(0.1, 1)))
+	if _, err := c.DoRequest("PATCH", "/projects/1/subscriptions/1", nil, nil, []int{200}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if _, err := c.DoRequest("GET", "/projects/1", nil, nil, []int{200}, nil); err != nil {
+		t.Fatalf("expected other endpoints not to be limited, got %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.DoRequestWithContext(ctx, "PATCH", "/projects/1/subscriptions/1", nil, nil, []int{200}, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the second subscription update to wait, got %v", err)
+	}
+}
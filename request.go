@@ -0,0 +1,49 @@
+package pushpad
+
+import "net/http"
+
+// RequestOption configures a single API request.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	header         http.Header
+	idempotencyKey string
+	operation      string
+
+	// filled while the request is performed
+	attempts     int
+	requestBytes int64
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{header: http.Header{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithHeader adds a header to the request.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithIdempotencyKey sends the request with an idempotency key. The client
+// remembers the successful response for that key and returns it when the same
+// request is repeated, instead of performing it again. Requests with an
+// idempotency key are also safe to retry.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithOperation names the API operation performed by the request, such as
+// "notification.Create", for instrumentation.
+func WithOperation(name string) RequestOption {
+	return func(o *requestOptions) {
+		o.operation = name
+	}
+}
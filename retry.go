@@ -0,0 +1,160 @@
+package pushpad
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// GET, HEAD, PUT and DELETE requests are retried after a network error or a
+// retryable status. POST and PATCH requests are not idempotent, so they are
+// retried only when the request could not have been processed by Pushpad
+// (a 429 response or a connection that was never established) or when they
+// carry an idempotency key, unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. The delay doubles
+	// after each attempt and a random jitter is applied.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, including the delay
+	// requested by the Retry-After header.
+	MaxBackoff time.Duration
+
+	// RetryableStatuses lists the HTTP status codes that are retried.
+	RetryableStatuses []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests after any
+	// retryable failure, even if the request may have reached the server.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts that retries rate
+// limiting and temporary server errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    500 * time.Millisecond,
+		MaxBackoff:        30 * time.Second,
+		RetryableStatuses: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// WithRetryPolicy enables automatic retries (disabled by default).
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// shouldRetry reports whether a request that completed with res or err can be
+// attempted again.
+func (p RetryPolicy) shouldRetry(attempt int, req *http.Request, res *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return p.canRepeat(req, err)
+	}
+	if !slices.Contains(p.RetryableStatuses, res.StatusCode) {
+		return false
+	}
+	return p.canRepeat(req, nil) || res.StatusCode == http.StatusTooManyRequests
+}
+
+// canRepeat reports whether sending req again cannot perform its action twice.
+func (p RetryPolicy) canRepeat(req *http.Request, err error) bool {
+	return p.RetryNonIdempotent || isIdempotent(req) || (err != nil && !wasSent(err))
+}
+
+// backoff returns the delay before the attempt that follows the given one.
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return delay
+		}
+	}
+
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// equal jitter: keep half of the delay and randomize the other half
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// parseRetryAfter parses a Retry-After header expressed in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// wasSent reports whether a network error may have happened after the request
+// reached the server. Only errors raised while dialing are known to be safe.
+func wasSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package pushpad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	var out struct{ ID int64 }
+	if _, err := c.DoRequest("GET", "/projects/1", nil, nil, []int{200}, &out); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+	if out.ID != 1 {
+		t.Errorf("expected ID 1, got %d", out.ID)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	_, err := c.DoRequest("GET", "/projects/1", nil, nil, []int{200}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("expected APIError with status 503, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryDoesNotRepeatPostAfterServerError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	_, err := c.DoRequest("POST", "/projects/1/notifications", nil, map[string]string{"body": "Hi"}, []int{201}, nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestRetryPostAfterTooManyRequests(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	if _, err := c.DoRequest("POST", "/projects/1/notifications", nil, map[string]string{"body": "Hi"}, []int{201}, nil); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(429)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxBackoff = time.Minute
+	c := NewClient("TOKEN", 123, WithBaseURL(server.URL), WithRetryPolicy(policy))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.DoRequestWithContext(ctx, "GET", "/projects/1", nil, nil, []int{200}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	if d, ok := parseRetryAfter("120", now); !ok || d != 2*time.Minute {
+		t.Errorf("expected 2m, got %s (%v)", d, ok)
+	}
+	if d, ok := parseRetryAfter("Wed, 01 Jan 2025 10:00:30 GMT", now); !ok || d != 30*time.Second {
+		t.Errorf("expected 30s, got %s (%v)", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Errorf("expected invalid value to be ignored")
+	}
+}
+
+func TestBackoffIsBounded(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second}
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := policy.backoff(attempt, nil)
+		if d < 0 || d > 4*time.Second {
+			t.Errorf("attempt %d: backoff %s out of bounds", attempt, d)
+		}
+	}
+}
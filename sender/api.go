@@ -1,44 +1,121 @@
 package sender
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pushpad/pushpad-go"
 )
 
+// Client performs sender API calls with a pushpad.Client.
+type Client struct {
+	backend *pushpad.Client
+}
+
+// NewClient returns a sender client that uses the given pushpad.Client.
+// A nil backend uses pushpad.DefaultClient().
+func NewClient(backend *pushpad.Client) *Client {
+	return &Client{backend: backend}
+}
+
+var defaultClient = &Client{}
+
+func (c *Client) api() *pushpad.Client {
+	if c.backend != nil {
+		return c.backend
+	}
+	return pushpad.DefaultClient()
+}
+
 func List(params *SenderListParams) ([]Sender, error) {
+	return defaultClient.List(params)
+}
+
+func ListWithContext(ctx context.Context, params *SenderListParams) ([]Sender, error) {
+	return defaultClient.ListWithContext(ctx, params)
+}
+
+func Create(params *SenderCreateParams) (*Sender, error) {
+	return defaultClient.Create(params)
+}
+
+func CreateWithContext(ctx context.Context, params *SenderCreateParams) (*Sender, error) {
+	return defaultClient.CreateWithContext(ctx, params)
+}
+
+func Get(senderID int64, params *SenderGetParams) (*Sender, error) {
+	return defaultClient.Get(senderID, params)
+}
+
+func GetWithContext(ctx context.Context, senderID int64, params *SenderGetParams) (*Sender, error) {
+	return defaultClient.GetWithContext(ctx, senderID, params)
+}
+
+func Update(senderID int64, params *SenderUpdateParams) (*Sender, error) {
+	return defaultClient.Update(senderID, params)
+}
+
+func UpdateWithContext(ctx context.Context, senderID int64, params *SenderUpdateParams) (*Sender, error) {
+	return defaultClient.UpdateWithContext(ctx, senderID, params)
+}
+
+func Delete(senderID int64, params *SenderDeleteParams) error {
+	return defaultClient.Delete(senderID, params)
+}
+
+func DeleteWithContext(ctx context.Context, senderID int64, params *SenderDeleteParams) error {
+	return defaultClient.DeleteWithContext(ctx, senderID, params)
+}
+
+func (c *Client) List(params *SenderListParams) ([]Sender, error) {
+	return c.ListWithContext(context.Background(), params)
+}
+
+func (c *Client) ListWithContext(ctx context.Context, params *SenderListParams) ([]Sender, error) {
 	var senders []Sender
-	_, err := pushpad.DoRequest("GET", "/senders", nil, nil, []int{200}, &senders)
+	_, err := c.api().DoRequestWithContext(ctx, "GET", "/senders", nil, nil, []int{200}, &senders, pushpad.WithOperation("sender.List"))
 	return senders, err
 }
 
-func Create(params *SenderCreateParams) (*Sender, error) {
+func (c *Client) Create(params *SenderCreateParams) (*Sender, error) {
+	return c.CreateWithContext(context.Background(), params)
+}
+
+func (c *Client) CreateWithContext(ctx context.Context, params *SenderCreateParams) (*Sender, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
 
 	var created Sender
-	_, err := pushpad.DoRequest("POST", "/senders", nil, params, []int{201}, &created)
+	_, err := c.api().DoRequestWithContext(ctx, "POST", "/senders", nil, params, []int{201}, &created, pushpad.WithOperation("sender.Create"))
 	if err != nil {
 		return nil, err
 	}
 	return &created, nil
 }
 
-func Get(senderID int64, params *SenderGetParams) (*Sender, error) {
+func (c *Client) Get(senderID int64, params *SenderGetParams) (*Sender, error) {
+	return c.GetWithContext(context.Background(), senderID, params)
+}
+
+func (c *Client) GetWithContext(ctx context.Context, senderID int64, params *SenderGetParams) (*Sender, error) {
 	if senderID == 0 {
 		return nil, fmt.Errorf("pushpad: sender ID is required")
 	}
 
 	var sender Sender
-	_, err := pushpad.DoRequest("GET", fmt.Sprintf("/senders/%d", senderID), nil, nil, []int{200}, &sender)
+	_, err := c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/senders/%d", senderID), nil, nil, []int{200}, &sender, pushpad.WithOperation("sender.Get"))
 	if err != nil {
 		return nil, err
 	}
 	return &sender, nil
 }
 
-func Update(senderID int64, params *SenderUpdateParams) (*Sender, error) {
+func (c *Client) Update(senderID int64, params *SenderUpdateParams) (*Sender, error) {
+	return c.UpdateWithContext(context.Background(), senderID, params)
+}
+
+func (c *Client) UpdateWithContext(ctx context.Context, senderID int64, params *SenderUpdateParams) (*Sender, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
@@ -47,17 +124,21 @@ func Update(senderID int64, params *SenderUpdateParams) (*Sender, error) {
 	}
 
 	var sender Sender
-	_, err := pushpad.DoRequest("PATCH", fmt.Sprintf("/senders/%d", senderID), nil, params, []int{200}, &sender)
+	_, err := c.api().DoRequestWithContext(ctx, "PATCH", fmt.Sprintf("/senders/%d", senderID), nil, params, []int{200}, &sender, pushpad.WithOperation("sender.Update"))
 	if err != nil {
 		return nil, err
 	}
 	return &sender, nil
 }
 
-func Delete(senderID int64, params *SenderDeleteParams) error {
+func (c *Client) Delete(senderID int64, params *SenderDeleteParams) error {
+	return c.DeleteWithContext(context.Background(), senderID, params)
+}
+
+func (c *Client) DeleteWithContext(ctx context.Context, senderID int64, params *SenderDeleteParams) error {
 	if senderID == 0 {
 		return fmt.Errorf("pushpad: sender ID is required")
 	}
-	_, err := pushpad.DoRequest("DELETE", fmt.Sprintf("/senders/%d", senderID), nil, nil, []int{204}, nil)
+	_, err := c.api().DoRequestWithContext(ctx, "DELETE", fmt.Sprintf("/senders/%d", senderID), nil, nil, []int{204}, nil, pushpad.WithOperation("sender.Delete"))
 	return err
 }
@@ -0,0 +1,20 @@
+package sender
+
+import "context"
+
+// SenderService is the set of sender API calls, implemented by *Client.
+// Depend on it instead of *Client to substitute a fake in tests.
+type SenderService interface {
+	List(params *SenderListParams) ([]Sender, error)
+	ListWithContext(ctx context.Context, params *SenderListParams) ([]Sender, error)
+	Create(params *SenderCreateParams) (*Sender, error)
+	CreateWithContext(ctx context.Context, params *SenderCreateParams) (*Sender, error)
+	Get(senderID int64, params *SenderGetParams) (*Sender, error)
+	GetWithContext(ctx context.Context, senderID int64, params *SenderGetParams) (*Sender, error)
+	Update(senderID int64, params *SenderUpdateParams) (*Sender, error)
+	UpdateWithContext(ctx context.Context, senderID int64, params *SenderUpdateParams) (*Sender, error)
+	Delete(senderID int64, params *SenderDeleteParams) error
+	DeleteWithContext(ctx context.Context, senderID int64, params *SenderDeleteParams) error
+}
+
+var _ SenderService = (*Client)(nil)
@@ -1,14 +1,38 @@
 package pushpad
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"log/slog"
 )
 
 // SignatureFor generates the HMAC signature for a user ID using the configured token.
+// If the default client has a credentials provider that fails, it logs the error
+// with the client logger and returns an empty string.
 func SignatureFor(uid string) string {
-	h := hmac.New(sha256.New, []byte(pushpadAuthToken))
+	c := DefaultClient()
+	signature, err := c.SignatureFor(uid)
+	if err != nil && c.logger != nil {
+		c.logger.LogAttrs(context.Background(), slog.LevelError, "pushpad signature", slog.String("error", err.Error()))
+	}
+	return signature
+}
+
+// SignatureFor generates the HMAC signature for a user ID using the token of the client.
+func (c *Client) SignatureFor(uid string) (string, error) {
+	return c.SignatureForWithContext(context.Background(), uid)
+}
+
+// SignatureForWithContext generates the HMAC signature for a user ID using the
+// token of the client. It returns the error of the credentials provider, if any.
+func (c *Client) SignatureForWithContext(ctx context.Context, uid string) (string, error) {
+	token, err := c.currentAuthToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	h := hmac.New(sha256.New, []byte(token))
 	h.Write([]byte(uid))
-	return hex.EncodeToString(h.Sum(nil))
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
@@ -1,6 +1,11 @@
 package pushpad
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -14,3 +19,20 @@ func TestSignatureFor(t *testing.T) {
 		t.Errorf("got %q, want %q", got, want)
 	}
 }
+
+func TestSignatureForProviderError(t *testing.T) {
+	var buf bytes.Buffer
+	provider := CredentialsProviderFunc(func(ctx context.Context) (string, error) {
+		return "", errors.New("vault unavailable")
+	})
+	previous := DefaultClient()
+	SetDefaultClient(NewClient("", 123, WithCredentialsProvider(provider), WithLogger(slog.New(slog.NewTextHandler(&buf, nil)))))
+	defer SetDefaultClient(previous)
+
+	if got := SignatureFor("user1"); got != "" {
+		t.Errorf("expected an empty signature, got %q", got)
+	}
+	if !strings.Contains(buf.String(), "vault unavailable") {
+		t.Errorf("expected the provider error to be logged, got %q", buf.String())
+	}
+}
@@ -1,18 +1,119 @@
 package subscription
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 
 	"github.com/pushpad/pushpad-go"
 )
 
+// Client performs subscription API calls with a pushpad.Client.
+type Client struct {
+	backend *pushpad.Client
+}
+
+// NewClient returns a subscription client that uses the given pushpad.Client.
+// A nil backend uses pushpad.DefaultClient().
+func NewClient(backend *pushpad.Client) *Client {
+	return &Client{backend: backend}
+}
+
+var defaultClient = &Client{}
+
+func (c *Client) api() *pushpad.Client {
+	if c.backend != nil {
+		return c.backend
+	}
+	return pushpad.DefaultClient()
+}
+
 func List(params *SubscriptionListParams) ([]Subscription, error) {
+	return defaultClient.List(params)
+}
+
+func ListWithContext(ctx context.Context, params *SubscriptionListParams) ([]Subscription, error) {
+	return defaultClient.ListWithContext(ctx, params)
+}
+
+// ListPage returns a page of subscriptions with the total count and pagination metadata.
+func ListPage(params *SubscriptionListParams) (*SubscriptionListResult, error) {
+	return defaultClient.ListPage(params)
+}
+
+func ListPageWithContext(ctx context.Context, params *SubscriptionListParams) (*SubscriptionListResult, error) {
+	return defaultClient.ListPageWithContext(ctx, params)
+}
+
+// All returns an iterator over the subscriptions that match params, fetching
+// the pages lazily. See Client.All.
+func All(ctx context.Context, params *SubscriptionListParams) iter.Seq2[Subscription, error] {
+	return defaultClient.All(ctx, params)
+}
+
+func Count(params *SubscriptionCountParams) (int64, error) {
+	return defaultClient.Count(params)
+}
+
+func CountWithContext(ctx context.Context, params *SubscriptionCountParams) (int64, error) {
+	return defaultClient.CountWithContext(ctx, params)
+}
+
+func Create(params *SubscriptionCreateParams) (*Subscription, error) {
+	return defaultClient.Create(params)
+}
+
+func CreateWithContext(ctx context.Context, params *SubscriptionCreateParams) (*Subscription, error) {
+	return defaultClient.CreateWithContext(ctx, params)
+}
+
+func Get(subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error) {
+	return defaultClient.Get(subscriptionID, params)
+}
+
+func GetWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error) {
+	return defaultClient.GetWithContext(ctx, subscriptionID, params)
+}
+
+func Update(subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error) {
+	return defaultClient.Update(subscriptionID, params)
+}
+
+func UpdateWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error) {
+	return defaultClient.UpdateWithContext(ctx, subscriptionID, params)
+}
+
+func Delete(subscriptionID int64, params *SubscriptionDeleteParams) error {
+	return defaultClient.Delete(subscriptionID, params)
+}
+
+func DeleteWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionDeleteParams) error {
+	return defaultClient.DeleteWithContext(ctx, subscriptionID, params)
+}
+
+func (c *Client) List(params *SubscriptionListParams) ([]Subscription, error) {
+	return c.ListWithContext(context.Background(), params)
+}
+
+func (c *Client) ListWithContext(ctx context.Context, params *SubscriptionListParams) ([]Subscription, error) {
+	result, err := c.ListPageWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result.Subscriptions, nil
+}
+
+func (c *Client) ListPage(params *SubscriptionListParams) (*SubscriptionListResult, error) {
+	return c.ListPageWithContext(context.Background(), params)
+}
+
+func (c *Client) ListPageWithContext(ctx context.Context, params *SubscriptionListParams) (*SubscriptionListResult, error) {
 	if params == nil {
 		params = &SubscriptionListParams{}
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -36,19 +137,61 @@ func List(params *SubscriptionListParams) ([]Subscription, error) {
 	}
 
 	var subscriptions []Subscription
-	_, err = pushpad.DoRequest("GET", fmt.Sprintf("/projects/%d/subscriptions", projectID), query, nil, []int{200}, &subscriptions)
+	res, err := c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/projects/%d/subscriptions", projectID), query, nil, []int{200}, &subscriptions, pushpad.WithOperation("subscription.List"))
 	if err != nil {
 		return nil, err
 	}
 
-	return subscriptions, nil
+	return &SubscriptionListResult{Subscriptions: subscriptions, ListMeta: pushpad.ParseListMeta(res)}, nil
 }
 
-func Count(params *SubscriptionCountParams) (int64, error) {
+// All returns an iterator over the subscriptions that match params, fetching
+// the pages lazily until they are exhausted. The UIDs and Tags filters are
+// applied to every page; Page sets the first page and PerPage the page size.
+// Iteration stops at the first error, which is yielded with a zero Subscription.
+func (c *Client) All(ctx context.Context, params *SubscriptionListParams) iter.Seq2[Subscription, error] {
+	return func(yield func(Subscription, error) bool) {
+		pageParams := SubscriptionListParams{}
+		if params != nil {
+			pageParams = *params
+		}
+		page := int64(1)
+		if pageParams.Page != nil && *pageParams.Page > 0 {
+			page = *pageParams.Page
+		}
+
+		for {
+			pageParams.Page = pushpad.Int64(page)
+			result, err := c.ListPageWithContext(ctx, &pageParams)
+			if err != nil {
+				yield(Subscription{}, err)
+				return
+			}
+			for _, subscription := range result.Subscriptions {
+				if !yield(subscription, nil) {
+					return
+				}
+			}
+			if len(result.Subscriptions) == 0 || (pageParams.PerPage != nil && int64(len(result.Subscriptions)) < *pageParams.PerPage) {
+				return
+			}
+			if result.LastPage > 0 && page >= result.LastPage {
+				return
+			}
+			page++
+		}
+	}
+}
+
+func (c *Client) Count(params *SubscriptionCountParams) (int64, error) {
+	return c.CountWithContext(context.Background(), params)
+}
+
+func (c *Client) CountWithContext(ctx context.Context, params *SubscriptionCountParams) (int64, error) {
 	if params == nil {
 		params = &SubscriptionCountParams{}
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return 0, err
 	}
@@ -64,7 +207,7 @@ func Count(params *SubscriptionCountParams) (int64, error) {
 			query.Add("tags[]", tag)
 		}
 	}
-	res, err := pushpad.DoRequest("HEAD", fmt.Sprintf("/projects/%d/subscriptions", projectID), query, nil, []int{200}, nil)
+	res, err := c.api().DoRequestWithContext(ctx, "HEAD", fmt.Sprintf("/projects/%d/subscriptions", projectID), query, nil, []int{200}, nil, pushpad.WithOperation("subscription.Count"))
 	if err != nil {
 		return 0, err
 	}
@@ -79,74 +222,90 @@ func Count(params *SubscriptionCountParams) (int64, error) {
 	return totalCount, nil
 }
 
-func Create(params *SubscriptionCreateParams) (*Subscription, error) {
+func (c *Client) Create(params *SubscriptionCreateParams) (*Subscription, error) {
+	return c.CreateWithContext(context.Background(), params)
+}
+
+func (c *Client) CreateWithContext(ctx context.Context, params *SubscriptionCreateParams) (*Subscription, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
 	var created Subscription
-	_, err = pushpad.DoRequest("POST", fmt.Sprintf("/projects/%d/subscriptions", projectID), nil, params, []int{201}, &created)
+	_, err = c.api().DoRequestWithContext(ctx, "POST", fmt.Sprintf("/projects/%d/subscriptions", projectID), nil, params, []int{201}, &created, pushpad.WithOperation("subscription.Create"))
 	if err != nil {
 		return nil, err
 	}
 	return &created, nil
 }
 
-func Get(subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error) {
+func (c *Client) Get(subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error) {
+	return c.GetWithContext(context.Background(), subscriptionID, params)
+}
+
+func (c *Client) GetWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error) {
 	if params == nil {
 		params = &SubscriptionGetParams{}
 	}
 	if subscriptionID == 0 {
 		return nil, fmt.Errorf("pushpad: subscription ID is required")
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
 	var subscription Subscription
-	_, err = pushpad.DoRequest("GET", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, nil, []int{200}, &subscription)
+	_, err = c.api().DoRequestWithContext(ctx, "GET", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, nil, []int{200}, &subscription, pushpad.WithOperation("subscription.Get"))
 	if err != nil {
 		return nil, err
 	}
 	return &subscription, nil
 }
 
-func Update(subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error) {
+func (c *Client) Update(subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error) {
+	return c.UpdateWithContext(context.Background(), subscriptionID, params)
+}
+
+func (c *Client) UpdateWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error) {
 	if params == nil {
 		return nil, fmt.Errorf("pushpad: params are required")
 	}
 	if subscriptionID == 0 {
 		return nil, fmt.Errorf("pushpad: subscription ID is required")
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
 	var subscription Subscription
-	_, err = pushpad.DoRequest("PATCH", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, params, []int{200}, &subscription)
+	_, err = c.api().DoRequestWithContext(ctx, "PATCH", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, params, []int{200}, &subscription, pushpad.WithOperation("subscription.Update"))
 	if err != nil {
 		return nil, err
 	}
 	return &subscription, nil
 }
 
-func Delete(subscriptionID int64, params *SubscriptionDeleteParams) error {
+func (c *Client) Delete(subscriptionID int64, params *SubscriptionDeleteParams) error {
+	return c.DeleteWithContext(context.Background(), subscriptionID, params)
+}
+
+func (c *Client) DeleteWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionDeleteParams) error {
 	if params == nil {
 		params = &SubscriptionDeleteParams{}
 	}
 	if subscriptionID == 0 {
 		return fmt.Errorf("pushpad: subscription ID is required")
 	}
-	projectID, err := pushpad.ResolveProjectID(params.ProjectID)
+	projectID, err := c.api().ResolveProjectID(params.ProjectID)
 	if err != nil {
 		return err
 	}
-	_, err = pushpad.DoRequest("DELETE", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, nil, []int{204}, nil)
+	_, err = c.api().DoRequestWithContext(ctx, "DELETE", fmt.Sprintf("/projects/%d/subscriptions/%d", projectID, subscriptionID), nil, nil, []int{204}, nil, pushpad.WithOperation("subscription.Delete"))
 	return err
 }
@@ -1,7 +1,9 @@
 package subscription
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -275,3 +277,187 @@ func TestDeleteSubscription(t *testing.T) {
 		t.Fatalf("expected no error, got %s", err)
 	}
 }
+
+func TestListSubscriptionsWithContext(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchHeader("Authorization", "Bearer TOKEN").
+		Reply(200).
+		BodyString(`[{"id":10}]`)
+
+	pushpad.Configure("TOKEN", 0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	subscriptions, err := ListWithContext(ctx, &SubscriptionListParams{ProjectID: pushpad.Int64(123)})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(subscriptions) != 1 {
+		t.Fatalf("expected 1 subscription, got %d", len(subscriptions))
+	}
+}
+
+func TestAllSubscriptions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "2").
+		MatchParam("tags[]", "sports").
+		Reply(200).
+		BodyString(`[{"id":1},{"id":2}]`)
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "2").
+		MatchParam("per_page", "2").
+		MatchParam("tags[]", "sports").
+		Reply(200).
+		BodyString(`[{"id":3}]`)
+
+	pushpad.Configure("TOKEN", 0)
+	params := &SubscriptionListParams{
+		ProjectID: pushpad.Int64(123),
+		PerPage:   pushpad.Int64(2),
+		Tags:      pushpad.StringSlice([]string{"sports"}),
+	}
+	var ids []int64
+	for subscription, err := range All(context.Background(), params) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		ids = append(ids, subscription.ID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("expected subscriptions [1 2 3], got %v", ids)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected both pages to be fetched")
+	}
+	if params.Page != nil {
+		t.Errorf("expected params not to be modified")
+	}
+}
+
+func TestAllSubscriptionsUntilEmptyPage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`[{"id":1}]`)
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "2").
+		Reply(200).
+		BodyString(`[]`)
+
+	pushpad.Configure("TOKEN", 123)
+	count := 0
+	for _, err := range All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("expected 1 subscription, got %d", count)
+	}
+}
+
+func TestAllSubscriptionsEarlyTermination(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`[{"id":1},{"id":2}]`)
+
+	pushpad.Configure("TOKEN", 123)
+	for subscription, err := range All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		if subscription.ID == 1 {
+			break
+		}
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected the first page to be fetched")
+	}
+}
+
+func TestAllSubscriptionsError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		Reply(500)
+
+	pushpad.Configure("TOKEN", 123)
+	var errs []error
+	for _, err := range All(context.Background(), nil) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], pushpad.ErrServer) {
+		t.Errorf("expected a single server error, got %v", errs)
+	}
+}
+
+func TestListPageSubscriptions(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "3").
+		MatchParam("per_page", "2").
+		Reply(200).
+		SetHeader("X-Total-Count", "80").
+		SetHeader("X-RateLimit-Remaining", "10").
+		BodyString(`[{"id":10},{"id":11}]`)
+
+	pushpad.Configure("TOKEN", 123)
+	result, err := ListPage(&SubscriptionListParams{Page: pushpad.Int64(3), PerPage: pushpad.Int64(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if len(result.Subscriptions) != 2 {
+		t.Fatalf("expected 2 subscriptions, got %d", len(result.Subscriptions))
+	}
+	if result.TotalCount != 80 {
+		t.Errorf("expected total count 80, got %d", result.TotalCount)
+	}
+	if result.Page != 3 || result.LastPage != 40 || result.NextPage != 4 || result.PrevPage != 2 {
+		t.Errorf("expected page 3 of 40, got %+v", result.ListMeta)
+	}
+	if result.RateLimit.Get("X-RateLimit-Remaining") != "10" {
+		t.Errorf("expected rate limit header, got %v", result.RateLimit)
+	}
+}
+
+func TestAllSubscriptionsStopsAtLastPage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://pushpad.xyz").
+		Get("/api/v1/projects/123/subscriptions").
+		MatchParam("page", "1").
+		Reply(200).
+		SetHeader("Link", `<https://pushpad.xyz/api/v1/projects/123/subscriptions?page=1>; rel="last"`).
+		BodyString(`[{"id":1}]`)
+
+	pushpad.Configure("TOKEN", 123)
+	count := 0
+	for _, err := range All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("expected 1 subscription, got %d", count)
+	}
+}
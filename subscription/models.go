@@ -1,6 +1,10 @@
 package subscription
 
-import "time"
+import (
+	"time"
+
+	"github.com/pushpad/pushpad-go"
+)
 
 // Subscription represents a Pushpad subscription.
 type Subscription struct {
@@ -41,6 +45,12 @@ type SubscriptionListParams struct {
 	Tags      *[]string
 }
 
+// SubscriptionListResult is a page of subscriptions with its pagination metadata.
+type SubscriptionListResult struct {
+	Subscriptions []Subscription
+	pushpad.ListMeta
+}
+
 // SubscriptionCountParams controls subscription counts.
 type SubscriptionCountParams struct {
 	ProjectID *int64
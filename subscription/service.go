@@ -0,0 +1,28 @@
+package subscription
+
+import (
+	"context"
+	"iter"
+)
+
+// SubscriptionService is the set of subscription API calls, implemented by *Client.
+// Depend on it instead of *Client to substitute a fake in tests.
+type SubscriptionService interface {
+	List(params *SubscriptionListParams) ([]Subscription, error)
+	ListWithContext(ctx context.Context, params *SubscriptionListParams) ([]Subscription, error)
+	ListPage(params *SubscriptionListParams) (*SubscriptionListResult, error)
+	ListPageWithContext(ctx context.Context, params *SubscriptionListParams) (*SubscriptionListResult, error)
+	All(ctx context.Context, params *SubscriptionListParams) iter.Seq2[Subscription, error]
+	Count(params *SubscriptionCountParams) (int64, error)
+	CountWithContext(ctx context.Context, params *SubscriptionCountParams) (int64, error)
+	Create(params *SubscriptionCreateParams) (*Subscription, error)
+	CreateWithContext(ctx context.Context, params *SubscriptionCreateParams) (*Subscription, error)
+	Get(subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error)
+	GetWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionGetParams) (*Subscription, error)
+	Update(subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error)
+	UpdateWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionUpdateParams) (*Subscription, error)
+	Delete(subscriptionID int64, params *SubscriptionDeleteParams) error
+	DeleteWithContext(ctx context.Context, subscriptionID int64, params *SubscriptionDeleteParams) error
+}
+
+var _ SubscriptionService = (*Client)(nil)
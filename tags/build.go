@@ -0,0 +1,95 @@
+package tags
+
+import "fmt"
+
+// Tag returns an expression that matches the subscriptions with the tag name.
+func Tag(name string) Expr {
+	return TagExpr{Name: name}
+}
+
+// Not returns an expression that matches the subscriptions that do not match x.
+func Not(x Expr) Expr {
+	return NotExpr{X: x}
+}
+
+// And returns an expression that matches the subscriptions that match all
+// the given expressions.
+func And(x Expr, more ...Expr) Expr {
+	for _, y := range more {
+		x = AndExpr{X: x, Y: y}
+	}
+	return x
+}
+
+// Or returns an expression that matches the subscriptions that match any of
+// the given expressions.
+func Or(x Expr, more ...Expr) Expr {
+	for _, y := range more {
+		x = OrExpr{X: x, Y: y}
+	}
+	return x
+}
+
+// ValidTag reports whether name is a valid tag: a non-empty string of at most
+// MaxTagLength letters, digits, underscores and colons.
+func ValidTag(name string) bool {
+	if name == "" || len(name) > MaxTagLength {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isTagChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the expression is complete and that its tags are valid.
+func Validate(x Expr) error {
+	switch x := x.(type) {
+	case nil:
+		return fmt.Errorf("pushpad: missing tag expression")
+	case TagExpr:
+		if !ValidTag(x.Name) {
+			return fmt.Errorf("pushpad: invalid tag %q", x.Name)
+		}
+		return nil
+	case NotExpr:
+		return Validate(x.X)
+	case AndExpr:
+		if err := Validate(x.X); err != nil {
+			return err
+		}
+		return Validate(x.Y)
+	case OrExpr:
+		if err := Validate(x.X); err != nil {
+			return err
+		}
+		return Validate(x.Y)
+	}
+	return fmt.Errorf("pushpad: unsupported tag expression %T", x)
+}
+
+// Build validates the expressions and renders them in canonical form, ready
+// for the Tags field of NotificationCreateParams, SubscriptionListParams and
+// SubscriptionCountParams. The expressions are joined with an implicit OR.
+func Build(exprs ...Expr) (*[]string, error) {
+	rendered := make([]string, 0, len(exprs))
+	for _, x := range exprs {
+		if err := Validate(x); err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, x.String())
+	}
+	return &rendered, nil
+}
+
+// MustBuild is like Build but panics if an expression is invalid. It is meant
+// for expressions built from constant tags.
+func MustBuild(exprs ...Expr) *[]string {
+	rendered, err := Build(exprs...)
+	if err != nil {
+		panic(err)
+	}
+	return rendered
+}
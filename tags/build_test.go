@@ -0,0 +1,85 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		expr Expr
+		want string
+	}{
+		{Tag("sports"), "sports"},
+		{And(Tag("sports"), Not(Tag("optout"))), "sports && !optout"},
+		{And(Or(Tag("a"), Tag("b")), Tag("c")), "(a || b) && c"},
+		{Or(And(Tag("a"), Tag("b")), Tag("c")), "a && b || c"},
+		{Not(Or(Tag("a"), Tag("b"))), "!(a || b)"},
+		{And(Tag("a"), Tag("b"), Tag("c")), "a && b && c"},
+		{And(Tag("a"), And(Tag("b"), Tag("c"))), "a && b && c"},
+		{Or(Tag("zip_code:28865")), "zip_code:28865"},
+	}
+	for _, tt := range tests {
+		got, err := Build(tt.expr)
+		if err != nil {
+			t.Errorf("Build(%#v): unexpected error %s", tt.expr, err)
+			continue
+		}
+		if len(*got) != 1 || (*got)[0] != tt.want {
+			t.Errorf("Build(%#v) = %q, want %q", tt.expr, *got, tt.want)
+		}
+
+		// the rendered expression parses back to an equivalent expression
+		parsed, err := Parse(tt.want)
+		if err != nil || parsed.String() != tt.want {
+			t.Errorf("Parse(%q) = %v, %v", tt.want, parsed, err)
+		}
+	}
+}
+
+func TestBuildMultiple(t *testing.T) {
+	got, err := Build(And(Tag("a"), Tag("b")), Tag("c"))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if strings.Join(*got, ",") != "a && b,c" {
+		t.Errorf("unexpected expressions %q", *got)
+	}
+}
+
+func TestBuildInvalid(t *testing.T) {
+	for _, expr := range []Expr{
+		Tag(""),
+		Tag("with space"),
+		Tag("a&&b"),
+		And(Tag("ok"), Not(Tag("é"))),
+		Tag(strings.Repeat("a", MaxTagLength+1)),
+		Not(nil),
+	} {
+		if _, err := Build(expr); err == nil {
+			t.Errorf("Build(%#v): expected an error", expr)
+		}
+	}
+}
+
+func TestMustBuildPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic")
+		}
+	}()
+	MustBuild(Tag("invalid tag"))
+}
+
+func TestValidTag(t *testing.T) {
+	for _, name := range []string{"sports", "zip_code:28865", "Organizer123"} {
+		if !ValidTag(name) {
+			t.Errorf("expected %q to be valid", name)
+		}
+	}
+	for _, name := range []string{"", "a b", "a-b", "!a"} {
+		if ValidTag(name) {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package tags parses and evaluates Pushpad tag expressions.
+//
+// The tags of a notification or a subscription filter are boolean expressions
+// over subscription tags, such as "zip_code:28865 && !optout:local_events".
+// The operators are ! (not), && (and) and || (or), in decreasing order of
+// precedence, and parentheses group subexpressions. The entries of a tags
+// array are joined with an implicit OR.
+package tags
+
+import "slices"
+
+// Expr is a parsed tag expression.
+type Expr interface {
+	// Match reports whether a subscription with the given tags matches the expression.
+	Match(tags []string) bool
+	// String returns the canonical form of the expression, with the minimal parentheses.
+	String() string
+
+	precedence() int
+}
+
+// Operator precedences, from the loosest to the tightest.
+const (
+	precOr = iota + 1
+	precAnd
+	precUnary
+)
+
+// TagExpr matches the subscriptions that have the tag Name.
+type TagExpr struct {
+	Name string
+}
+
+// NotExpr matches the subscriptions that do not match X.
+type NotExpr struct {
+	X Expr
+}
+
+// AndExpr matches the subscriptions that match both X and Y.
+type AndExpr struct {
+	X, Y Expr
+}
+
+// OrExpr matches the subscriptions that match X or Y.
+type OrExpr struct {
+	X, Y Expr
+}
+
+func (e TagExpr) Match(tags []string) bool { return slices.Contains(tags, e.Name) }
+func (e NotExpr) Match(tags []string) bool { return !e.X.Match(tags) }
+func (e AndExpr) Match(tags []string) bool { return e.X.Match(tags) && e.Y.Match(tags) }
+func (e OrExpr) Match(tags []string) bool  { return e.X.Match(tags) || e.Y.Match(tags) }
+
+func (e TagExpr) String() string { return e.Name }
+func (e NotExpr) String() string { return "!" + operand(e.X, precUnary) }
+func (e AndExpr) String() string { return operand(e.X, precAnd) + " && " + operand(e.Y, precAnd) }
+func (e OrExpr) String() string  { return operand(e.X, precOr) + " || " + operand(e.Y, precOr) }
+
+func (TagExpr) precedence() int { return precUnary }
+func (NotExpr) precedence() int { return precUnary }
+func (AndExpr) precedence() int { return precAnd }
+func (OrExpr) precedence() int  { return precOr }
+
+// operand renders x as the operand of an operator with precedence prec.
+func operand(x Expr, prec int) string {
+	if x.precedence() < prec {
+		return "(" + x.String() + ")"
+	}
+	return x.String()
+}
+
+// Match reports whether a subscription with the given tags matches a tags
+// array, such as NotificationCreateParams.Tags, whose entries are joined with
+// an implicit OR. An empty array matches every subscription.
+func Match(exprs []string, tags []string) (bool, error) {
+	expr, err := ParseList(exprs)
+	if err != nil {
+		return false, err
+	}
+	return expr == nil || expr.Match(tags), nil
+}
@@ -0,0 +1,45 @@
+package tags
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	exprs := []string{"zip_code:28865 && !optout:local_events", "friend_of:Organizer123"}
+	tests := []struct {
+		tags []string
+		want bool
+	}{
+		{[]string{"zip_code:28865"}, true},
+		{[]string{"zip_code:28865", "optout:local_events"}, false},
+		{[]string{"zip_code:28865", "optout:local_events", "friend_of:Organizer123"}, true},
+		{[]string{"zip_code:10000"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		got, err := Match(exprs, tt.tags)
+		if err != nil {
+			t.Fatalf("unexpected error %s", err)
+		}
+		if got != tt.want {
+			t.Errorf("Match(%v) = %t, want %t", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestMatchEmpty(t *testing.T) {
+	if ok, err := Match(nil, []string{"a"}); !ok || err != nil {
+		t.Errorf("expected an empty array to match, got %t, %v", ok, err)
+	}
+	if _, err := Match([]string{"a &"}, nil); err == nil {
+		t.Errorf("expected a syntax error")
+	}
+}
+
+func TestExprMatch(t *testing.T) {
+	expr, err := Parse("(a || b) && !c")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !expr.Match([]string{"b"}) || expr.Match([]string{"a", "c"}) || expr.Match([]string{"d"}) {
+		t.Errorf("unexpected match result for %s", expr)
+	}
+}
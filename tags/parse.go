@@ -0,0 +1,194 @@
+package tags
+
+import "fmt"
+
+// SyntaxError describes an invalid tag expression.
+type SyntaxError struct {
+	// Expr is the invalid expression.
+	Expr string
+	// Offset is the byte offset of the error in Expr.
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("pushpad: invalid tag expression %q: %s at offset %d", e.Expr, e.Msg, e.Offset)
+}
+
+// Parse parses a tag expression.
+func Parse(s string) (Expr, error) {
+	p := &parser{src: s}
+	p.next()
+	expr := p.parseOr()
+	if p.err == nil && p.tok != tokEOF {
+		p.fail("unexpected " + p.describe())
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return expr, nil
+}
+
+// ParseList parses the entries of a tags array and joins them with OR. It
+// returns a nil Expr when exprs is empty.
+func ParseList(exprs []string) (Expr, error) {
+	var result Expr
+	for _, s := range exprs {
+		expr, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			result = expr
+		} else {
+			result = OrExpr{result, expr}
+		}
+	}
+	return result, nil
+}
+
+// MaxTagLength is the maximum length of a tag.
+const MaxTagLength = 255
+
+// isTagChar reports whether c can be part of a tag: letters, digits,
+// underscores and colons.
+func isTagChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == ':'
+}
+
+type token int
+
+const (
+	tokEOF token = iota
+	tokTag
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokInvalid
+)
+
+type parser struct {
+	src string
+	pos int
+
+	// current token
+	tok    token
+	offset int
+	text   string
+
+	err *SyntaxError
+}
+
+func (p *parser) fail(msg string) {
+	if p.err == nil {
+		p.err = &SyntaxError{Expr: p.src, Offset: p.offset, Msg: msg}
+	}
+}
+
+// next scans the next token.
+func (p *parser) next() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	p.offset = p.pos
+	if p.pos == len(p.src) {
+		p.tok, p.text = tokEOF, ""
+		return
+	}
+
+	start := p.pos
+	switch c := p.src[p.pos]; {
+	case isTagChar(c):
+		for p.pos < len(p.src) && isTagChar(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = tokTag
+	case c == '&' || c == '|':
+		if p.pos+1 < len(p.src) && p.src[p.pos+1] == c {
+			p.pos += 2
+			p.tok = tokAnd
+			if c == '|' {
+				p.tok = tokOr
+			}
+		} else {
+			p.pos++
+			p.tok = tokInvalid
+		}
+	case c == '!':
+		p.pos++
+		p.tok = tokNot
+	case c == '(':
+		p.pos++
+		p.tok = tokLParen
+	case c == ')':
+		p.pos++
+		p.tok = tokRParen
+	default:
+		p.pos++
+		p.tok = tokInvalid
+	}
+	p.text = p.src[start:p.pos]
+}
+
+func (p *parser) describe() string {
+	switch p.tok {
+	case tokEOF:
+		return "end of expression"
+	case tokTag:
+		return fmt.Sprintf("tag %q", p.text)
+	default:
+		return fmt.Sprintf("%q", p.text)
+	}
+}
+
+// parseOr parses and-expressions joined by ||.
+func (p *parser) parseOr() Expr {
+	x := p.parseAnd()
+	for p.err == nil && p.tok == tokOr {
+		p.next()
+		x = OrExpr{x, p.parseAnd()}
+	}
+	return x
+}
+
+// parseAnd parses unary expressions joined by &&.
+func (p *parser) parseAnd() Expr {
+	x := p.parseUnary()
+	for p.err == nil && p.tok == tokAnd {
+		p.next()
+		x = AndExpr{x, p.parseUnary()}
+	}
+	return x
+}
+
+// parseUnary parses a tag, a negation or a parenthesized expression.
+func (p *parser) parseUnary() Expr {
+	if p.err != nil {
+		return nil
+	}
+	switch p.tok {
+	case tokTag:
+		if len(p.text) > MaxTagLength {
+			p.fail(fmt.Sprintf("tag longer than %d characters", MaxTagLength))
+			return nil
+		}
+		x := TagExpr{p.text}
+		p.next()
+		return x
+	case tokNot:
+		p.next()
+		return NotExpr{p.parseUnary()}
+	case tokLParen:
+		p.next()
+		x := p.parseOr()
+		if p.err == nil && p.tok != tokRParen {
+			p.fail("expected ) instead of " + p.describe())
+		}
+		p.next()
+		return x
+	}
+	p.fail("expected tag instead of " + p.describe())
+	return nil
+}
@@ -0,0 +1,92 @@
+package tags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"sports", "sports"},
+		{"zip_code:28865 && !optout:local_events || friend_of:Organizer123", "zip_code:28865 && !optout:local_events || friend_of:Organizer123"},
+		{"a||b&&c", "a || b && c"},
+		{"(a || b) && c", "(a || b) && c"},
+		{"((a))", "a"},
+		{"!!a", "!!a"},
+		{"!(a && b)", "!(a && b)"},
+		{"a && (b && c)", "a && b && c"},
+		{" a\t&&  b ", "a && b"},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error %s", tt.expr, err)
+			continue
+		}
+		if got := expr.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	expr, err := Parse("a || b && !c")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	want := OrExpr{TagExpr{"a"}, AndExpr{TagExpr{"b"}, NotExpr{TagExpr{"c"}}}}
+	if expr != want {
+		t.Errorf("unexpected tree %#v", expr)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+		msg    string
+	}{
+		{"", 0, "expected tag instead of end of expression"},
+		{"a &&", 4, "expected tag instead of end of expression"},
+		{"a & b", 2, `unexpected "&"`},
+		{"(a || b", 7, "expected ) instead of end of expression"},
+		{"a b", 2, `unexpected tag "b"`},
+		{"a)", 1, `unexpected ")"`},
+		{"a && é", 5, `expected tag instead of "\xc3"`},
+		{"!", 1, "expected tag instead of end of expression"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q): expected a syntax error, got %v", tt.expr, err)
+			continue
+		}
+		if syntaxErr.Offset != tt.offset {
+			t.Errorf("Parse(%q): expected offset %d, got %d (%s)", tt.expr, tt.offset, syntaxErr.Offset, err)
+		}
+		if syntaxErr.Msg != tt.msg {
+			t.Errorf("Parse(%q): expected message %q, got %q", tt.expr, tt.msg, syntaxErr.Msg)
+		}
+	}
+}
+
+func TestParseList(t *testing.T) {
+	expr, err := ParseList([]string{"a && b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if got := expr.String(); got != "a && b || c" {
+		t.Errorf("unexpected expression %q", got)
+	}
+
+	if expr, err := ParseList(nil); expr != nil || err != nil {
+		t.Errorf("expected nil expression, got %v, %v", expr, err)
+	}
+	if _, err := ParseList([]string{"a", "||"}); err == nil {
+		t.Errorf("expected an error")
+	}
+}